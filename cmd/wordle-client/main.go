@@ -14,9 +14,14 @@ import (
 func main() {
 	// Command line flags
 	serverURL := flag.String("server", "http://localhost:8080", "server URL (for online modes)")
-	mode := flag.String("mode", "", "game mode: offline, single, or multi (if not specified, will prompt)")
+	mode := flag.String("mode", "", "game mode: offline, single, multi, or daily (if not specified, will prompt)")
 	configPath := flag.String("config", "cfg/config.yaml", "path to configuration file (for offline mode)")
 	wordsPath := flag.String("words", "", "path to words list file (for offline mode, overrides config)")
+	hardMode := flag.Bool("hard", false, "hard mode: revealed hints must be used in subsequent guesses")
+	daily := flag.Bool("daily", false, "play today's daily puzzle (offline and single modes)")
+	absurdle := flag.Bool("absurdle", false, "adversarial mode: the answer dodges your guesses (offline and single modes)")
+	boards := flag.Int("boards", 1, "number of boards to play at once, up to 8 (offline and single modes)")
+	resume := flag.Bool("resume", false, "continue the offline game saved when you last quit")
 	flag.Parse()
 
 	// Show welcome message
@@ -27,6 +32,10 @@ func main() {
 
 	// Determine game mode
 	gameMode := *mode
+	if gameMode == "" && *resume {
+		// Only offline games are saved
+		gameMode = "offline"
+	}
 	if gameMode == "" {
 		gameMode = promptMode()
 	}
@@ -37,16 +46,33 @@ func main() {
 		// Offline standalone mode (Task 1)
 		fmt.Println("\n→ Starting Offline Mode (no server required)...")
 		runner := cli.NewRunner(os.Stdin, *configPath, *wordsPath)
+		runner.SetHardMode(*hardMode)
+		runner.SetDaily(*daily)
+		runner.SetAbsurdle(*absurdle)
+		runner.SetBoards(*boards)
+		runner.SetResume(*resume)
 		err = runner.Run()
 	case "single", "1":
 		// Single-player online mode (Task 2)
 		fmt.Println("\n→ Starting Online Single-Player Mode...")
 		app := client.NewApp(*serverURL, os.Stdin)
+		app.SetHardMode(*hardMode)
+		app.SetDaily(*daily)
+		app.SetAbsurdle(*absurdle)
+		app.SetBoards(*boards)
 		err = app.Run()
 	case "multi", "2":
 		// Multi-player online mode (Task 4)
 		fmt.Println("\n→ Starting Online Multi-Player Mode...")
 		app := client.NewRoomApp(*serverURL, os.Stdin)
+		app.SetHardMode(*hardMode)
+		err = app.Run()
+	case "daily", "3":
+		// Daily puzzle, online (offline: -mode offline -daily)
+		fmt.Println("\n→ Starting Online Daily Puzzle...")
+		app := client.NewApp(*serverURL, os.Stdin)
+		app.SetHardMode(*hardMode)
+		app.SetDaily(true)
 		err = app.Run()
 	default:
 		fmt.Fprintf(os.Stderr, "Invalid mode: %s\n", gameMode)
@@ -67,7 +93,8 @@ func promptMode() string {
 		fmt.Println("  0. Offline      (standalone, no server required)")
 		fmt.Println("  1. Single-Player (online, connect to server)")
 		fmt.Println("  2. Multi-Player  (online, race against friends)")
-		fmt.Print("\nEnter choice (0, 1, or 2): ")
+		fmt.Println("  3. Daily Puzzle  (online, same word for everyone today)")
+		fmt.Print("\nEnter choice (0, 1, 2, or 3): ")
 
 		input, _ := reader.ReadString('\n')
 		input = strings.TrimSpace(input)
@@ -79,8 +106,10 @@ func promptMode() string {
 			return "single"
 		case "2", "multi":
 			return "multi"
+		case "3", "daily":
+			return "daily"
 		default:
-			fmt.Println("Invalid choice. Please enter 0, 1, or 2.\n")
+			fmt.Print("Invalid choice. Please enter 0, 1, 2, or 3.\n\n")
 		}
 	}
 }
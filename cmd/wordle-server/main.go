@@ -1,8 +1,13 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"flag"
 	"log"
+	"os"
+	"os/signal"
+	"syscall"
 
 	"github.com/admin/wordle/internal/config"
 	"github.com/admin/wordle/pkg/server"
@@ -13,13 +18,17 @@ func main() {
 	configPath := flag.String("config", "cfg/config.yaml", "path to configuration file")
 	wordsPath := flag.String("words", "", "path to words list file (overrides config word_list)")
 	port := flag.String("port", "8080", "server port")
+	mode := flag.String("mode", "", "server mode: release or debug (overrides config mode)")
 	flag.Parse()
 
 	// Load configuration
 	cfg, err := config.LoadConfig(*configPath)
-	if err != nil {
-		log.Printf("Warning: failed to load config: %v, using defaults", err)
+	switch {
+	case errors.Is(err, config.ErrNoConfigFile):
+		log.Printf("Warning: %v, using defaults", err)
 		cfg = config.DefaultConfig()
+	case err != nil:
+		log.Fatalf("Failed to load config: %v", err)
 	}
 
 	// If words file is specified, load words from file and override config word_list
@@ -28,13 +37,28 @@ func main() {
 		if err != nil {
 			log.Fatalf("Failed to load words file: %v", err)
 		}
-		cfg.WordList = words
+		cfg.WordList = cfg.NormalizeWords(words)
 		log.Printf("Loaded %d words from %s", len(words), *wordsPath)
 	}
 
+	if *mode != "" {
+		cfg.Mode = *mode
+		if err := cfg.ValidateLogging(); err != nil {
+			log.Fatalf("Invalid -mode: %v", err)
+		}
+	}
+
 	// Create and start server application
-	app := server.NewApp(cfg, *port)
-	if err := app.Start(); err != nil {
-		log.Fatalf("Server failed to start: %v", err)
+	app, err := server.NewApp(cfg, *port)
+	if err != nil {
+		log.Fatalf("Failed to create server: %v", err)
+	}
+
+	// Shut down gracefully on Ctrl+C and on SIGTERM from rolling restarts
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if err := app.Start(ctx); err != nil {
+		log.Fatalf("Server failed: %v", err)
 	}
 }
@@ -2,20 +2,146 @@ package config
 
 import (
 	"errors"
+	"fmt"
+	"io/fs"
 	"os"
+	"path/filepath"
+	"strings"
+	"time"
 
+	"github.com/admin/wordle/internal/game"
 	"gopkg.in/yaml.v3"
 )
 
 // Config represents the game configuration
 type Config struct {
-	MaxRounds int      `yaml:"max_rounds"`
-	WordList  []string `yaml:"word_list"`
+	MaxRounds  int      `yaml:"max_rounds"`
+	WordLength int      `yaml:"word_length"`
+	HardMode   bool     `yaml:"hard_mode"`
+	WordList   []string `yaml:"word_list"`
+
+	// Allowed guesses, in addition to the word list
+	// Leave both empty to accept any valid word as a guess
+	AllowedGuesses     []string `yaml:"allowed_guesses"`
+	AllowedGuessesFile string   `yaml:"allowed_guesses_file"` // Relative to the config file's directory
+
+	// Daily puzzle: the answer is derived from the date, epoch and seed
+	DailyEpoch string `yaml:"daily_epoch"` // Date of puzzle #1 (YYYY-MM-DD)
+	DailySeed  string `yaml:"daily_seed"`
+
+	// Word pack language rules: words and guesses are upper-cased with the
+	// language's case rules and, optionally, stripped of accents (É as E)
+	Language       string `yaml:"language"` // en, es, de, ru or tr; empty means en
+	FoldDiacritics bool   `yaml:"fold_diacritics"`
+
+	// Turn off GET /game/:id/suggest for competitive play
+	DisableSuggestions bool `yaml:"disable_suggestions"`
+
+	// List the remaining candidate words, not just their count
+	ListCandidates bool `yaml:"list_candidates"`
+
+	// Persistence for game sessions and rooms: "memory" keeps them only until
+	// the server stops, "file" saves them under storage_path across restarts
+	Storage     string `yaml:"storage"`
+	StoragePath string `yaml:"storage_path"`
+
+	// Janitor: abandoned games and rooms are removed once they have been
+	// inactive for their TTL; a negative TTL keeps them forever
+	JanitorInterval time.Duration `yaml:"janitor_interval"`
+	GameIdleTTL     time.Duration `yaml:"game_idle_ttl"`     // Games and rooms in progress
+	FinishedGameTTL time.Duration `yaml:"finished_game_ttl"` // Finished games and rooms
+	EmptyRoomTTL    time.Duration `yaml:"empty_room_ttl"`    // Rooms every player has left
+	WaitingRoomTTL  time.Duration `yaml:"waiting_room_ttl"`  // Rooms that never started
+
+	// HTTP server timeouts; write_timeout must exceed the 30s long poll
+	ReadTimeout     time.Duration `yaml:"read_timeout"`
+	WriteTimeout    time.Duration `yaml:"write_timeout"`
+	IdleTimeout     time.Duration `yaml:"idle_timeout"`
+	ShutdownTimeout time.Duration `yaml:"shutdown_timeout"` // Time allowed to drain requests on SIGINT/SIGTERM
+	DrainDelay      time.Duration `yaml:"drain_delay"`      // Time /readyz reports draining before shutdown; negative skips it
+
+	// Abuse protection: per-client token-bucket rate limits and caps on what
+	// clients can hold; a negative value turns a limit off
+	RateLimit         float64  `yaml:"rate_limit"`           // Requests per second per client IP
+	RateBurst         int      `yaml:"rate_burst"`           // Requests allowed at once per client IP
+	TokenRateLimit    float64  `yaml:"token_rate_limit"`     // Requests per second per player token
+	TokenRateBurst    int      `yaml:"token_rate_burst"`     // Requests allowed at once per player token
+	MaxGamesPerClient int      `yaml:"max_games_per_client"` // Unfinished single-player games played in the last 10m, per client IP
+	MaxRooms          int      `yaml:"max_rooms"`            // Rooms held by the server
+	MaxRoomListeners  int      `yaml:"max_room_listeners"`   // Long polls, event streams and WebSockets per room
+	TrustedProxies    []string `yaml:"trusted_proxies"`      // Proxies whose X-Forwarded-For gives the client IP; none by default
+
+	// Server mode and logging
+	Mode      string `yaml:"mode"`       // "release", or "debug" for gin's debug output and debug logs
+	LogFormat string `yaml:"log_format"` // "text" or "json"
+	LogLevel  string `yaml:"log_level"`  // debug, info, warn or error
 }
 
+// Daily puzzle defaults
+const (
+	DefaultDailyEpoch = "2024-01-01"
+	DefaultDailySeed  = "wordle"
+)
+
+// Storage backends
+const (
+	StorageMemory      = "memory"
+	StorageFile        = "file"
+	DefaultStoragePath = "data"
+)
+
+// Janitor defaults
+const (
+	DefaultJanitorInterval = time.Minute
+	DefaultGameIdleTTL     = 24 * time.Hour
+	DefaultFinishedGameTTL = time.Hour
+	DefaultEmptyRoomTTL    = 5 * time.Minute
+	DefaultWaitingRoomTTL  = time.Hour
+)
+
+// Server modes
+const (
+	ModeRelease = "release"
+	ModeDebug   = "debug"
+)
+
+// Log formats and the default level
+const (
+	LogFormatText   = "text"
+	LogFormatJSON   = "json"
+	DefaultLogLevel = "info"
+)
+
+// HTTP server defaults
+const (
+	DefaultReadTimeout     = 15 * time.Second
+	DefaultWriteTimeout    = time.Minute
+	DefaultIdleTimeout     = 2 * time.Minute
+	DefaultShutdownTimeout = 15 * time.Second
+	DefaultDrainDelay      = 5 * time.Second
+)
+
+// Abuse protection defaults
+const (
+	DefaultRateLimit         = 10.0
+	DefaultRateBurst         = 30
+	DefaultTokenRateLimit    = 5.0
+	DefaultTokenRateBurst    = 15
+	DefaultMaxGamesPerClient = 20
+	DefaultMaxRooms          = 1000
+	DefaultMaxRoomListeners  = 64
+)
+
+// ErrNoConfigFile is returned by LoadConfig when the file does not exist
+// Callers may fall back to DefaultConfig; any other error is a broken config.
+var ErrNoConfigFile = errors.New("configuration file not found")
+
 // LoadConfig loads configuration from a YAML file
 func LoadConfig(filename string) (*Config, error) {
 	data, err := os.ReadFile(filename)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("%w: %s", ErrNoConfigFile, filename)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -30,43 +156,236 @@ func LoadConfig(filename string) (*Config, error) {
 		return nil, errors.New("max_rounds must be positive")
 	}
 
+	if config.WordLength == 0 {
+		config.WordLength = game.DefaultWordLength
+	}
+	if !game.ValidWordLength(config.WordLength) {
+		return nil, fmt.Errorf("word_length must be between %d and %d", game.MinWordLength, game.MaxWordLength)
+	}
+
 	if len(config.WordList) == 0 {
 		return nil, errors.New("word list cannot be empty")
 	}
 
+	if !game.ValidLanguage(config.Language) {
+		return nil, fmt.Errorf("unsupported language: %s", config.Language)
+	}
+
+	if config.DailyEpoch == "" {
+		config.DailyEpoch = DefaultDailyEpoch
+	}
+	if _, err := config.DailyEpochTime(); err != nil {
+		return nil, fmt.Errorf("daily_epoch must be a YYYY-MM-DD date: %w", err)
+	}
+	if config.DailySeed == "" {
+		config.DailySeed = DefaultDailySeed
+	}
+
+	switch config.Storage {
+	case "":
+		config.Storage = StorageMemory
+	case StorageMemory, StorageFile:
+	default:
+		return nil, fmt.Errorf("unknown storage backend: %s", config.Storage)
+	}
+	if config.StoragePath == "" {
+		config.StoragePath = DefaultStoragePath
+	}
+
+	config.applyJanitorDefaults()
+	if config.JanitorInterval < 0 {
+		return nil, errors.New("janitor_interval must be positive")
+	}
+
+	config.applyHTTPDefaults()
+	if config.ReadTimeout < 0 || config.WriteTimeout < 0 || config.IdleTimeout < 0 || config.ShutdownTimeout < 0 {
+		return nil, errors.New("server timeouts must be positive")
+	}
+
+	config.applyLimitDefaults()
+
+	config.applyLogDefaults()
+	if err := config.ValidateLogging(); err != nil {
+		return nil, err
+	}
+
+	// Load the allowed-guess dictionary file, if configured
+	if config.AllowedGuessesFile != "" {
+		path := config.AllowedGuessesFile
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(filepath.Dir(filename), path)
+		}
+		words, err := LoadWordsFromFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load allowed guesses file: %w", err)
+		}
+		config.AllowedGuesses = append(config.AllowedGuesses, words...)
+	}
+
+	config.WordList = config.NormalizeWords(config.WordList)
+	config.AllowedGuesses = config.NormalizeWords(config.AllowedGuesses)
+
 	return &config, nil
 }
 
-// LoadWordsFromFile loads words from a text file (one word per line)
+// applyJanitorDefaults fills in janitor settings left unset
+func (c *Config) applyJanitorDefaults() {
+	defaults := []struct {
+		value    *time.Duration
+		fallback time.Duration
+	}{
+		{&c.JanitorInterval, DefaultJanitorInterval},
+		{&c.GameIdleTTL, DefaultGameIdleTTL},
+		{&c.FinishedGameTTL, DefaultFinishedGameTTL},
+		{&c.EmptyRoomTTL, DefaultEmptyRoomTTL},
+		{&c.WaitingRoomTTL, DefaultWaitingRoomTTL},
+	}
+	for _, d := range defaults {
+		if *d.value == 0 {
+			*d.value = d.fallback
+		}
+	}
+}
+
+// applyHTTPDefaults fills in HTTP server settings left unset
+func (c *Config) applyHTTPDefaults() {
+	defaults := []struct {
+		value    *time.Duration
+		fallback time.Duration
+	}{
+		{&c.ReadTimeout, DefaultReadTimeout},
+		{&c.WriteTimeout, DefaultWriteTimeout},
+		{&c.IdleTimeout, DefaultIdleTimeout},
+		{&c.ShutdownTimeout, DefaultShutdownTimeout},
+		{&c.DrainDelay, DefaultDrainDelay},
+	}
+	for _, d := range defaults {
+		if *d.value == 0 {
+			*d.value = d.fallback
+		}
+	}
+}
+
+// applyLimitDefaults fills in rate limits and caps left unset
+func (c *Config) applyLimitDefaults() {
+	rates := []struct {
+		value    *float64
+		fallback float64
+	}{
+		{&c.RateLimit, DefaultRateLimit},
+		{&c.TokenRateLimit, DefaultTokenRateLimit},
+	}
+	for _, d := range rates {
+		if *d.value == 0 {
+			*d.value = d.fallback
+		}
+	}
+
+	caps := []struct {
+		value    *int
+		fallback int
+	}{
+		{&c.RateBurst, DefaultRateBurst},
+		{&c.TokenRateBurst, DefaultTokenRateBurst},
+		{&c.MaxGamesPerClient, DefaultMaxGamesPerClient},
+		{&c.MaxRooms, DefaultMaxRooms},
+		{&c.MaxRoomListeners, DefaultMaxRoomListeners},
+	}
+	for _, d := range caps {
+		if *d.value == 0 {
+			*d.value = d.fallback
+		}
+	}
+}
+
+// applyLogDefaults fills in the server mode and logging settings left unset
+func (c *Config) applyLogDefaults() {
+	if c.Mode == "" {
+		c.Mode = ModeRelease
+	}
+	if c.LogFormat == "" {
+		c.LogFormat = LogFormatText
+	}
+	if c.LogLevel == "" {
+		c.LogLevel = DefaultLogLevel
+	}
+}
+
+// ValidateLogging checks the server mode and logging settings
+// Call it again after overriding Mode from a command line flag.
+func (c *Config) ValidateLogging() error {
+	switch c.Mode {
+	case ModeRelease, ModeDebug:
+	default:
+		return fmt.Errorf("unknown server mode: %s", c.Mode)
+	}
+	switch c.LogFormat {
+	case LogFormatText, LogFormatJSON:
+	default:
+		return fmt.Errorf("unknown log format: %s", c.LogFormat)
+	}
+	switch c.LogLevel {
+	case "debug", "info", "warn", "error":
+	default:
+		return fmt.Errorf("unknown log level: %s", c.LogLevel)
+	}
+	return nil
+}
+
+// Dictionary returns the allowed-guess dictionary, including every word in
+// the word list, or nil when no allowed guesses are configured
+func (c *Config) Dictionary() game.Dictionary {
+	if len(c.AllowedGuesses) == 0 {
+		return nil
+	}
+	return game.NewDictionary(c.AllowedGuesses, c.WordList)
+}
+
+// Normalization returns the language rules for words and guesses
+func (c *Config) Normalization() game.Normalization {
+	return game.Normalization{
+		Language:       c.Language,
+		FoldDiacritics: c.FoldDiacritics,
+	}
+}
+
+// NormalizeWords applies the language rules to a word list
+// Word lists loaded after LoadConfig must go through it too
+func (c *Config) NormalizeWords(words []string) []string {
+	return c.Normalization().NormalizeWords(words)
+}
+
+// DailyEpochTime returns the parsed date of daily puzzle #1
+func (c *Config) DailyEpochTime() (time.Time, error) {
+	return time.Parse(game.DailyDateLayout, c.DailyEpoch)
+}
+
+// LoadWordsFromFile loads words from a UTF-8 text file (one word per line)
 func LoadWordsFromFile(filename string) ([]string, error) {
 	data, err := os.ReadFile(filename)
 	if err != nil {
 		return nil, err
 	}
 
-	lines := []string{}
-	current := ""
-	for _, b := range data {
-		if b == '\n' || b == '\r' {
-			if current != "" {
-				lines = append(lines, current)
-				current = ""
-			}
-		} else {
-			current += string(b)
-		}
-	}
-	if current != "" {
-		lines = append(lines, current)
-	}
+	// Editors on Windows often save word packs with a byte order mark
+	text := strings.TrimPrefix(string(data), "\uFEFF")
+
+	lines := strings.FieldsFunc(text, func(r rune) bool {
+		return r == '\n' || r == '\r'
+	})
 
 	return lines, nil
 }
 
 // DefaultConfig returns a default configuration
 func DefaultConfig() *Config {
-	return &Config{
-		MaxRounds: 6,
+	cfg := &Config{
+		MaxRounds:   6,
+		WordLength:  game.DefaultWordLength,
+		DailyEpoch:  DefaultDailyEpoch,
+		DailySeed:   DefaultDailySeed,
+		Storage:     StorageMemory,
+		StoragePath: DefaultStoragePath,
 		WordList: []string{
 			"CRANE", "SLATE", "ABOUT", "APPLE", "HOUSE",
 			"WORLD", "THINK", "GREAT", "PLACE", "BRAIN",
@@ -74,4 +393,9 @@ func DefaultConfig() *Config {
 			"OCEAN", "PIANO", "BREAD", "MUSIC", "TABLE",
 		},
 	}
+	cfg.applyJanitorDefaults()
+	cfg.applyHTTPDefaults()
+	cfg.applyLimitDefaults()
+	cfg.applyLogDefaults()
+	return cfg
 }
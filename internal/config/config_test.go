@@ -0,0 +1,85 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+// writeFile writes content to name under dir and returns its path
+func writeFile(t *testing.T, dir, name, content string) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoadConfigAllowedGuessesFile(t *testing.T) {
+	dir := t.TempDir()
+	absolute := writeFile(t, dir, "packs/absolute.txt", "SLATE\n")
+	writeFile(t, dir, "cfg/allowed.txt", "CRANE\nBRAIN\n")
+
+	tests := []struct {
+		name string
+		file string
+		want []string
+	}{
+		{"relative to the config file", "allowed.txt", []string{"CRANE", "BRAIN"}},
+		{"absolute", absolute, []string{"SLATE"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := writeFile(t, dir, "cfg/config.yaml",
+				"max_rounds: 6\nword_list: [ABOUT]\nallowed_guesses_file: \""+tt.file+"\"\n")
+
+			// Loading must not depend on the working directory
+			t.Chdir(t.TempDir())
+			cfg, err := LoadConfig(path)
+			if err != nil {
+				t.Fatalf("LoadConfig() error = %v", err)
+			}
+			if !slices.Equal(cfg.AllowedGuesses, tt.want) {
+				t.Errorf("AllowedGuesses = %v, want %v", cfg.AllowedGuesses, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoadConfigErrors(t *testing.T) {
+	tests := []struct {
+		name      string
+		content   string // Empty: no file
+		noFileErr bool
+	}{
+		{"missing file", "", true},
+		{"invalid YAML", "max_rounds: [", false},
+		{"invalid setting", "max_rounds: 0\nword_list: [ABOUT]\n", false},
+		{"missing allowed guesses file", "max_rounds: 6\nword_list: [ABOUT]\nallowed_guesses_file: missing.txt\n", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			path := filepath.Join(dir, "config.yaml")
+			if tt.content != "" {
+				writeFile(t, dir, "config.yaml", tt.content)
+			}
+
+			_, err := LoadConfig(path)
+			if err == nil {
+				t.Fatal("LoadConfig() should return error")
+			}
+			if got := errors.Is(err, ErrNoConfigFile); got != tt.noFileErr {
+				t.Errorf("errors.Is(%v, ErrNoConfigFile) = %v, want %v", err, got, tt.noFileErr)
+			}
+		})
+	}
+}
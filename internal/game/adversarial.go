@@ -0,0 +1,99 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+)
+
+// NewAdversarialGame creates an "Absurdle" game with no fixed answer
+// The game keeps every word still consistent with the feedback so far and
+// answers each guess with the feedback that keeps the most words alive.
+// It has no round limit and ends only when the guess is the last candidate.
+func NewAdversarialGame(wordLength int, wordList []string) (*Game, error) {
+	if !ValidWordLength(wordLength) {
+		return nil, fmt.Errorf("word length must be between %d and %d", MinWordLength, MaxWordLength)
+	}
+	if len(wordList) == 0 {
+		return nil, errors.New("word list cannot be empty")
+	}
+
+	validWords := dedupeWords(FilterWords(wordList, wordLength))
+	if len(validWords) == 0 {
+		return nil, fmt.Errorf("no valid %d-letter words in word list", wordLength)
+	}
+
+	return &Game{
+		MaxRounds:    0, // Unlimited
+		WordLength:   wordLength,
+		WordList:     validWords,
+		CurrentRound: 0,
+		History:      []GuessResult{},
+		Status:       InProgress,
+		Adversarial:  true,
+		Candidates:   validWords,
+	}, nil
+}
+
+// narrowCandidates picks the feedback for a guess that keeps the largest
+// bucket of candidates alive, and keeps only that bucket
+// Ties go to the feedback revealing the fewest Hits, then the fewest Present
+// letters, so the game gives away as little as possible.
+// Must only be called on adversarial games.
+func (g *Game) narrowCandidates(guess string) GuessResult {
+	type bucket struct {
+		result GuessResult
+		words  []string
+	}
+
+	buckets := make(map[string]*bucket)
+	order := []string{}
+	for _, candidate := range g.Candidates {
+		result := EvaluateGuess(guess, candidate)
+		key := FormatResult(result)
+		if _, exists := buckets[key]; !exists {
+			buckets[key] = &bucket{result: result}
+			order = append(order, key)
+		}
+		buckets[key].words = append(buckets[key].words, candidate)
+	}
+
+	var best *bucket
+	for _, key := range order {
+		b := buckets[key]
+		if best == nil || betterBucket(b.result, len(b.words), best.result, len(best.words)) {
+			best = b
+		}
+	}
+
+	g.Candidates = best.words
+	if len(best.words) == 1 && best.words[0] == guess {
+		g.Answer = guess
+	}
+	return best.result
+}
+
+// betterBucket reports whether bucket a is a better choice for the adversary than bucket b
+func betterBucket(a GuessResult, aSize int, b GuessResult, bSize int) bool {
+	if aSize != bSize {
+		return aSize > bSize
+	}
+	aHits, aPresent := countStatuses(a)
+	bHits, bPresent := countStatuses(b)
+	if aHits != bHits {
+		return aHits < bHits
+	}
+	return aPresent < bPresent
+}
+
+// countStatuses counts the Hit and Present letters in a result
+func countStatuses(result GuessResult) (hits, present int) {
+	for _, status := range result.Statuses {
+		switch status {
+		case Hit:
+			hits++
+		case Present:
+			present++
+		}
+	}
+	return hits, present
+}
@@ -0,0 +1,67 @@
+package game
+
+import (
+	"testing"
+)
+
+func TestAdversarialGame(t *testing.T) {
+	wordList := []string{"APPLE", "BRAIN", "CRANE", "DREAM", "EARTH", "GRAPE"}
+	game, err := NewAdversarialGame(5, wordList)
+	if err != nil {
+		t.Fatalf("NewAdversarialGame() error = %v, want nil", err)
+	}
+
+	if game.Answer != "" {
+		t.Errorf("NewAdversarialGame() Answer = %s, want empty", game.Answer)
+	}
+
+	// Guessing a candidate never wins while other candidates remain
+	result, err := game.MakeGuess("CRANE")
+	if err != nil {
+		t.Fatalf("MakeGuess() error = %v, want nil", err)
+	}
+	if game.Status != InProgress {
+		t.Errorf("After first guess, Status = %v, want InProgress", game.Status)
+	}
+
+	// Every remaining candidate must be consistent with the feedback given
+	for _, candidate := range game.Candidates {
+		if FormatResult(EvaluateGuess("CRANE", candidate)) != FormatResult(result) {
+			t.Errorf("Candidate %s is inconsistent with feedback %s", candidate, FormatResult(result))
+		}
+	}
+
+	// Keep guessing candidates until the set collapses
+	for rounds := 0; game.Status == InProgress; rounds++ {
+		if rounds > len(wordList) {
+			t.Fatal("Adversarial game did not end")
+		}
+		if _, err := game.MakeGuess(game.Candidates[0]); err != nil {
+			t.Fatalf("MakeGuess() error = %v, want nil", err)
+		}
+	}
+
+	if game.Status != Won {
+		t.Errorf("Final Status = %v, want Won", game.Status)
+	}
+	if len(game.Candidates) != 1 || game.Answer != game.Candidates[0] {
+		t.Errorf("Final Answer = %s, Candidates = %v, want a single matching candidate", game.Answer, game.Candidates)
+	}
+	if FormatResult(game.History[len(game.History)-1]) != "OOOOO" {
+		t.Errorf("Winning guess result = %s, want OOOOO", FormatResult(game.History[len(game.History)-1]))
+	}
+}
+
+func TestAdversarialGameLargestBucket(t *testing.T) {
+	// CRANE splits these into {BRAIN, DRAIN, GRAIN} (_OO?_), {CRANE} (OOOOO)
+	// and {PLUMB} (_____); the largest bucket must survive
+	game, _ := NewAdversarialGame(5, []string{"BRAIN", "DRAIN", "GRAIN", "CRANE", "PLUMB"})
+
+	result, _ := game.MakeGuess("CRANE")
+	if FormatResult(result) != "_OO?_" {
+		t.Errorf("MakeGuess(\"CRANE\") = %s, want _OO?_", FormatResult(result))
+	}
+	if len(game.Candidates) != 3 {
+		t.Errorf("Candidates = %v, want 3 words", game.Candidates)
+	}
+}
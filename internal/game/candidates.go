@@ -0,0 +1,38 @@
+package game
+
+// ConsistentWords returns the words that would have produced exactly the
+// feedback recorded in history, i.e. the answers still possible
+func ConsistentWords(wordList []string, history []GuessResult) []string {
+	consistent := []string{}
+	for _, word := range wordList {
+		if consistentWith(word, history) {
+			consistent = append(consistent, word)
+		}
+	}
+	return consistent
+}
+
+// consistentWith checks if an answer explains every result in history
+func consistentWith(answer string, history []GuessResult) bool {
+	for _, prev := range history {
+		result := EvaluateGuess(prev.Guess, answer)
+		if len(result.Statuses) != len(prev.Statuses) {
+			return false
+		}
+		for i, status := range result.Statuses {
+			if status != prev.Statuses[i] {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// RemainingCandidates returns the words from the word list that are still
+// consistent with every guess so far
+func (g *Game) RemainingCandidates() []string {
+	if g.Adversarial {
+		return append([]string{}, g.Candidates...)
+	}
+	return ConsistentWords(dedupeWords(g.WordList), g.History)
+}
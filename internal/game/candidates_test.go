@@ -0,0 +1,28 @@
+package game
+
+import "testing"
+
+func TestRemainingCandidates(t *testing.T) {
+	words := []string{"CRATE", "CRANE", "GRACE", "TRACE", "BRAIN", "CRATE"}
+	game, _ := NewGame(6, 5, words)
+	game.Answer = "CRATE"
+
+	if remaining := game.RemainingCandidates(); len(remaining) != 5 {
+		t.Errorf("RemainingCandidates() before guessing = %v, want 5 distinct words", remaining)
+	}
+
+	game.MakeGuess("TRACE")
+	remaining := game.RemainingCandidates()
+	if len(remaining) != 1 || remaining[0] != "CRATE" {
+		t.Errorf("RemainingCandidates() after TRACE = %v, want [CRATE]", remaining)
+	}
+}
+
+func TestRemainingCandidatesAdversarial(t *testing.T) {
+	game, _ := NewAdversarialGame(5, []string{"CRATE", "CRANE", "BRAIN"})
+	game.MakeGuess("BRAIN")
+
+	if remaining := game.RemainingCandidates(); len(remaining) != len(game.Candidates) {
+		t.Errorf("RemainingCandidates() = %v, want %v", remaining, game.Candidates)
+	}
+}
@@ -0,0 +1,78 @@
+package game
+
+import (
+	"errors"
+	"hash/fnv"
+	"sort"
+	"time"
+)
+
+// DailyDateLayout is the calendar date format used for daily puzzles
+const DailyDateLayout = "2006-01-02"
+
+// DailyPuzzleNumber returns the 1-based puzzle number for a calendar date,
+// counting days since the epoch. Only the year, month and day of each time
+// are used, so callers decide which time zone a "day" belongs to.
+func DailyPuzzleNumber(date, epoch time.Time) (int, error) {
+	day := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC)
+	start := time.Date(epoch.Year(), epoch.Month(), epoch.Day(), 0, 0, 0, 0, time.UTC)
+	if day.Before(start) {
+		return 0, errors.New("date is before the daily puzzle epoch")
+	}
+	return int(day.Sub(start).Hours()/24) + 1, nil
+}
+
+// DailyAnswer returns the answer for a puzzle number
+// The word list is deduplicated and shuffled by a hash of the seed, so every
+// word appears once before any word repeats and the order cannot be guessed
+// from the list itself. The result only depends on the inputs.
+func DailyAnswer(number int, seed string, validWords []string) string {
+	words := dedupeWords(validWords)
+	keys := make(map[string]uint64, len(words))
+	for _, word := range words {
+		h := fnv.New64a()
+		h.Write([]byte(seed))
+		h.Write([]byte{0})
+		h.Write([]byte(word))
+		keys[word] = h.Sum64()
+	}
+	sort.Slice(words, func(i, j int) bool {
+		if keys[words[i]] != keys[words[j]] {
+			return keys[words[i]] < keys[words[j]]
+		}
+		return words[i] < words[j]
+	})
+	return words[(number-1)%len(words)]
+}
+
+// NewDailyGame creates the daily puzzle game for a calendar date
+// Everyone using the same word list, epoch and seed gets the same answer
+func NewDailyGame(maxRounds, wordLength int, wordList []string, date, epoch time.Time, seed string) (*Game, error) {
+	number, err := DailyPuzzleNumber(date, epoch)
+	if err != nil {
+		return nil, err
+	}
+
+	g, err := NewGame(maxRounds, wordLength, wordList)
+	if err != nil {
+		return nil, err
+	}
+
+	g.Answer = DailyAnswer(number, seed, g.WordList)
+	g.PuzzleNumber = number
+	g.PuzzleDate = date.Format(DailyDateLayout)
+	return g, nil
+}
+
+// dedupeWords returns the distinct words in the list, in first-seen order
+func dedupeWords(wordList []string) []string {
+	seen := make(map[string]bool, len(wordList))
+	words := make([]string, 0, len(wordList))
+	for _, word := range wordList {
+		if !seen[word] {
+			seen[word] = true
+			words = append(words, word)
+		}
+	}
+	return words
+}
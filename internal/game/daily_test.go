@@ -0,0 +1,83 @@
+package game
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDailyPuzzleNumber(t *testing.T) {
+	epoch := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		date   time.Time
+		number int
+	}{
+		{time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), 1},
+		{time.Date(2024, 1, 1, 23, 59, 0, 0, time.UTC), 1},
+		{time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC), 2},
+		{time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC), 367}, // 2024 is a leap year
+		// Only the calendar date matters, not the time zone offset
+		{time.Date(2024, 3, 31, 23, 0, 0, 0, time.FixedZone("UTC+10", 10*3600)), 91},
+	}
+
+	for _, tt := range tests {
+		number, err := DailyPuzzleNumber(tt.date, epoch)
+		if err != nil {
+			t.Errorf("DailyPuzzleNumber(%v) error = %v, want nil", tt.date, err)
+			continue
+		}
+		if number != tt.number {
+			t.Errorf("DailyPuzzleNumber(%v) = %d, want %d", tt.date, number, tt.number)
+		}
+	}
+
+	if _, err := DailyPuzzleNumber(epoch.AddDate(0, 0, -1), epoch); err == nil {
+		t.Error("DailyPuzzleNumber() before epoch should return error")
+	}
+}
+
+func TestDailyAnswer(t *testing.T) {
+	words := []string{"APPLE", "BRAIN", "CRANE", "DREAM", "EARTH"}
+
+	// Same inputs always give the same answer
+	if DailyAnswer(3, "team", words) != DailyAnswer(3, "team", words) {
+		t.Error("DailyAnswer() should be deterministic")
+	}
+
+	// List order and duplicates do not change the answer
+	shuffled := []string{"EARTH", "CRANE", "APPLE", "DREAM", "BRAIN", "CRANE"}
+	if DailyAnswer(3, "team", words) != DailyAnswer(3, "team", shuffled) {
+		t.Error("DailyAnswer() should not depend on word list order")
+	}
+
+	// Every word is used once per cycle
+	seen := make(map[string]bool)
+	for number := 1; number <= len(words); number++ {
+		seen[DailyAnswer(number, "team", words)] = true
+	}
+	if len(seen) != len(words) {
+		t.Errorf("DailyAnswer() used %d distinct words in %d days, want %d", len(seen), len(words), len(words))
+	}
+}
+
+func TestNewDailyGame(t *testing.T) {
+	epoch := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	date := time.Date(2024, 2, 1, 8, 0, 0, 0, time.UTC)
+	words := []string{"APPLE", "BRAIN", "CRANE"}
+
+	g1, err := NewDailyGame(6, 5, words, date, epoch, "team")
+	if err != nil {
+		t.Fatalf("NewDailyGame() error = %v, want nil", err)
+	}
+	g2, _ := NewDailyGame(6, 5, words, date.Add(10*time.Hour), epoch, "team")
+
+	if g1.Answer != g2.Answer {
+		t.Errorf("NewDailyGame() answers differ on the same day: %s, %s", g1.Answer, g2.Answer)
+	}
+	if g1.PuzzleNumber != 32 {
+		t.Errorf("NewDailyGame() PuzzleNumber = %d, want 32", g1.PuzzleNumber)
+	}
+	if g1.PuzzleDate != "2024-02-01" {
+		t.Errorf("NewDailyGame() PuzzleDate = %s, want 2024-02-01", g1.PuzzleDate)
+	}
+}
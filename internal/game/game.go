@@ -2,8 +2,8 @@ package game
 
 import (
 	"errors"
+	"fmt"
 	"math/rand"
-	"strings"
 )
 
 // GameStatus represents the current status of the game
@@ -18,36 +18,52 @@ const (
 	Lost
 )
 
+// Errors returned for rejected guesses
+var (
+	ErrNotInWordList = errors.New("not in word list") // Not in the allowed-guess dictionary
+	ErrInvalidWord   = errors.New("invalid word")     // Wrong length or not alphabetic
+	ErrGameOver      = errors.New("game is already over")
+)
+
 // Game represents a Wordle game instance
 type Game struct {
 	Answer       string
 	MaxRounds    int
+	WordLength   int
 	WordList     []string
 	CurrentRound int
 	History      []GuessResult
 	Status       GameStatus
+	HardMode     bool       // Revealed hints must be used in subsequent guesses
+	Dictionary   Dictionary // Allowed guesses; nil accepts any valid word
+	PuzzleNumber int        // Daily puzzle number; 0 for random games
+	PuzzleDate   string     // Daily puzzle date (YYYY-MM-DD); empty for random games
+
+	// Language rules applied to guesses; the word list must follow the same rules
+	Normalization Normalization
+
+	// Adversarial ("Absurdle") games have no fixed Answer until won
+	Adversarial bool
+	Candidates  []string // Words still consistent with every guess so far
 }
 
 // NewGame creates a new Wordle game with the given configuration
-func NewGame(maxRounds int, wordList []string) (*Game, error) {
+func NewGame(maxRounds, wordLength int, wordList []string) (*Game, error) {
 	if maxRounds <= 0 {
 		return nil, errors.New("max rounds must be positive")
 	}
+	if !ValidWordLength(wordLength) {
+		return nil, fmt.Errorf("word length must be between %d and %d", MinWordLength, MaxWordLength)
+	}
 	if len(wordList) == 0 {
 		return nil, errors.New("word list cannot be empty")
 	}
 
-	// Validate all words in the list
-	validWords := []string{}
-	for _, word := range wordList {
-		word = strings.TrimSpace(word)
-		if ValidateWord(word) {
-			validWords = append(validWords, strings.ToUpper(word))
-		}
-	}
+	// Keep only the words valid for the configured length
+	validWords := FilterWords(wordList, wordLength)
 
 	if len(validWords) == 0 {
-		return nil, errors.New("no valid words in word list")
+		return nil, fmt.Errorf("no valid %d-letter words in word list", wordLength)
 	}
 
 	// Select a random word as the answer
@@ -56,6 +72,7 @@ func NewGame(maxRounds int, wordList []string) (*Game, error) {
 	return &Game{
 		Answer:       answer,
 		MaxRounds:    maxRounds,
+		WordLength:   wordLength,
 		WordList:     validWords,
 		CurrentRound: 0,
 		History:      []GuessResult{},
@@ -70,14 +87,16 @@ func NewGameWithAnswer(maxRounds int, answer string) (*Game, error) {
 		return nil, errors.New("max rounds must be positive")
 	}
 
-	answer = strings.ToUpper(strings.TrimSpace(answer))
-	if !ValidateWord(answer) {
+	answer = NormalizeWord(answer)
+	wordLength := len(Letters(answer))
+	if !ValidWordLength(wordLength) || !ValidateWordLength(answer, wordLength) {
 		return nil, errors.New("invalid answer word")
 	}
 
 	return &Game{
 		Answer:       answer,
 		MaxRounds:    maxRounds,
+		WordLength:   wordLength,
 		WordList:     []string{answer},
 		CurrentRound: 0,
 		History:      []GuessResult{},
@@ -92,37 +111,64 @@ func GetRandomInt(n int) int {
 
 // MakeGuess processes a player's guess and updates the game state
 func (g *Game) MakeGuess(guess string) (GuessResult, error) {
+	guess, err := g.checkGuess(guess)
+	if err != nil {
+		return GuessResult{}, err
+	}
+	return g.applyGuess(guess), nil
+}
+
+// checkGuess validates a guess without changing the game state
+// Returns the guess normalized with the game's language rules
+func (g *Game) checkGuess(guess string) (string, error) {
 	if g.Status != InProgress {
-		return GuessResult{}, errors.New("game is already over")
+		return "", ErrGameOver
+	}
+
+	guess = g.Normalization.Normalize(guess)
+	if !ValidateWordLength(guess, g.WordLength) {
+		return "", fmt.Errorf("%w: must be %d letters, alphabetic only", ErrInvalidWord, g.WordLength)
 	}
 
-	guess = strings.TrimSpace(guess)
-	if !ValidateWord(guess) {
-		return GuessResult{}, errors.New("invalid word: must be 5 letters, alphabetic only")
+	// Reject words outside the allowed-guess dictionary (the answer is always allowed)
+	if g.Dictionary != nil && guess != g.Answer && !g.Dictionary.Contains(guess) {
+		return "", fmt.Errorf("%w: %s", ErrNotInWordList, guess)
 	}
 
-	guess = strings.ToUpper(guess)
+	// In hard mode, every revealed hint must be reused
+	if g.HardMode {
+		if err := CheckHardMode(guess, g.History); err != nil {
+			return "", err
+		}
+	}
 
-	// Optional: Check if the guess is in the word list
-	// For now, we'll allow any valid 5-letter word
+	return guess, nil
+}
 
+// applyGuess records a checked guess and updates the game status
+func (g *Game) applyGuess(guess string) GuessResult {
 	g.CurrentRound++
-	result := EvaluateGuess(guess, g.Answer)
+	var result GuessResult
+	if g.Adversarial {
+		result = g.narrowCandidates(guess)
+	} else {
+		result = EvaluateGuess(guess, g.Answer)
+	}
 	g.History = append(g.History, result)
 
 	// Check if the player won
 	if guess == g.Answer {
 		g.Status = Won
-		return result, nil
+		return result
 	}
 
-	// Check if the player lost
-	if g.CurrentRound >= g.MaxRounds {
+	// Check if the player lost (MaxRounds 0 means unlimited)
+	if g.MaxRounds > 0 && g.CurrentRound >= g.MaxRounds {
 		g.Status = Lost
-		return result, nil
+		return result
 	}
 
-	return result, nil
+	return result
 }
 
 // IsGameOver checks if the game has ended
@@ -136,6 +182,10 @@ func (g *Game) GetStatus() GameStatus {
 }
 
 // GetRemainingRounds returns the number of remaining rounds
+// Games without a round limit always report -1
 func (g *Game) GetRemainingRounds() int {
+	if g.MaxRounds == 0 {
+		return -1
+	}
 	return g.MaxRounds - g.CurrentRound
 }
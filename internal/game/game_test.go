@@ -1,13 +1,14 @@
 package game
 
 import (
+	"errors"
 	"testing"
 )
 
 func TestNewGame(t *testing.T) {
 	// Test valid game creation
 	wordList := []string{"APPLE", "BRAIN", "CRANE"}
-	game, err := NewGame(6, wordList)
+	game, err := NewGame(6, 5, wordList)
 
 	if err != nil {
 		t.Errorf("NewGame() error = %v, want nil", err)
@@ -26,22 +27,71 @@ func TestNewGame(t *testing.T) {
 	}
 
 	// Test invalid max rounds
-	_, err = NewGame(0, wordList)
+	_, err = NewGame(0, 5, wordList)
 	if err == nil {
-		t.Error("NewGame(0, wordList) should return error")
+		t.Error("NewGame(0, 5, wordList) should return error")
 	}
 
 	// Test empty word list
-	_, err = NewGame(6, []string{})
+	_, err = NewGame(6, 5, []string{})
 	if err == nil {
-		t.Error("NewGame(6, []) should return error")
+		t.Error("NewGame(6, 5, []) should return error")
+	}
+
+	// Test unsupported word lengths
+	for _, length := range []int{MinWordLength - 1, MaxWordLength + 1} {
+		if _, err := NewGame(6, length, wordList); err == nil {
+			t.Errorf("NewGame(6, %d, wordList) should return error", length)
+		}
+	}
+
+	// Test no words of the requested length
+	_, err = NewGame(6, 6, wordList)
+	if err == nil {
+		t.Error("NewGame(6, 6, wordList) without 6-letter words should return error")
+	}
+}
+
+func TestGameWordLength(t *testing.T) {
+	// Only the 6-letter words should be kept
+	wordList := []string{"APPLE", "planet", "BRAIN", "GARDEN"}
+	game, err := NewGame(6, 6, wordList)
+	if err != nil {
+		t.Fatalf("NewGame() error = %v, want nil", err)
+	}
+
+	if game.WordLength != 6 {
+		t.Errorf("NewGame() WordLength = %d, want 6", game.WordLength)
+	}
+
+	if len(game.WordList) != 2 {
+		t.Errorf("NewGame() WordList = %v, want 2 words", game.WordList)
+	}
+
+	// A 5-letter guess is rejected without using up a round
+	if _, err := game.MakeGuess("APPLE"); err == nil {
+		t.Error("MakeGuess(\"APPLE\") in a 6-letter game should return error")
+	}
+	if game.CurrentRound != 0 {
+		t.Errorf("After invalid guess, CurrentRound = %d, want 0", game.CurrentRound)
+	}
+
+	result, err := game.MakeGuess(game.Answer)
+	if err != nil {
+		t.Errorf("MakeGuess() error = %v, want nil", err)
+	}
+	if len(result.Statuses) != 6 {
+		t.Errorf("MakeGuess() returned %d statuses, want 6", len(result.Statuses))
+	}
+	if game.Status != Won {
+		t.Errorf("After correct guess, Status = %v, want Won", game.Status)
 	}
 }
 
 func TestGameFlow(t *testing.T) {
 	// Create a game with known answer
 	wordList := []string{"APPLE"}
-	game, _ := NewGame(6, wordList)
+	game, _ := NewGame(6, 5, wordList)
 
 	// Make a wrong guess
 	_, err := game.MakeGuess("BRAIN")
@@ -77,7 +127,7 @@ func TestGameFlow(t *testing.T) {
 func TestGameLoss(t *testing.T) {
 	// Create a game with 2 max rounds
 	wordList := []string{"APPLE"}
-	game, _ := NewGame(2, wordList)
+	game, _ := NewGame(2, 5, wordList)
 
 	// Make 2 wrong guesses
 	game.MakeGuess("BRAIN")
@@ -94,7 +144,7 @@ func TestGameLoss(t *testing.T) {
 
 func TestInvalidGuess(t *testing.T) {
 	wordList := []string{"APPLE"}
-	game, _ := NewGame(6, wordList)
+	game, _ := NewGame(6, 5, wordList)
 
 	// Test invalid guesses
 	invalidGuesses := []string{
@@ -113,3 +163,30 @@ func TestInvalidGuess(t *testing.T) {
 		}
 	}
 }
+
+func TestDictionary(t *testing.T) {
+	game, _ := NewGame(6, 5, []string{"APPLE"})
+	game.Dictionary = NewDictionary([]string{"brain", "CRANE"})
+
+	// Non-words are rejected without using up a round
+	_, err := game.MakeGuess("AAAAA")
+	if !errors.Is(err, ErrNotInWordList) {
+		t.Errorf("MakeGuess(\"AAAAA\") error = %v, want ErrNotInWordList", err)
+	}
+	if game.CurrentRound != 0 {
+		t.Errorf("After rejected guess, CurrentRound = %d, want 0", game.CurrentRound)
+	}
+
+	// Dictionary lookups are case-insensitive
+	if _, err := game.MakeGuess("Brain"); err != nil {
+		t.Errorf("MakeGuess(\"Brain\") error = %v, want nil", err)
+	}
+
+	// The answer is always accepted
+	if _, err := game.MakeGuess("APPLE"); err != nil {
+		t.Errorf("MakeGuess(\"APPLE\") error = %v, want nil", err)
+	}
+	if game.Status != Won {
+		t.Errorf("After correct guess, Status = %v, want Won", game.Status)
+	}
+}
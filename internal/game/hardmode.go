@@ -0,0 +1,67 @@
+package game
+
+import "fmt"
+
+// HardModeError is returned when a guess ignores a hint revealed by an earlier guess
+type HardModeError struct {
+	// Letter is the revealed letter the guess failed to use
+	Letter string
+	// Position is the 0-based position the letter must occupy for a Hit
+	// constraint, or -1 for a Present constraint
+	Position int
+}
+
+// Error implements the error interface
+func (e *HardModeError) Error() string {
+	if e.Position >= 0 {
+		return fmt.Sprintf("hard mode: letter %d must be %s", e.Position+1, e.Letter)
+	}
+	return fmt.Sprintf("hard mode: guess must contain %s", e.Letter)
+}
+
+// CheckHardMode verifies that a guess uses every hint revealed in history:
+// 1. Every Hit letter must stay in its position
+// 2. Every Present letter must appear somewhere in the guess
+// Letters revealed more than once in a single guess must be reused as many times
+func CheckHardMode(guess string, history []GuessResult) error {
+	guessLetters := Letters(NormalizeWord(guess))
+
+	guessLetterCount := make(map[string]int)
+	for _, letter := range guessLetters {
+		guessLetterCount[letter]++
+	}
+
+	for _, prev := range history {
+		prevLetters := Letters(prev.Guess)
+
+		// First pass: Hit letters must stay in place
+		for i, status := range prev.Statuses {
+			if status != Hit || i >= len(prevLetters) {
+				continue
+			}
+			if i >= len(guessLetters) || guessLetters[i] != prevLetters[i] {
+				return &HardModeError{Letter: prevLetters[i], Position: i}
+			}
+		}
+
+		// Second pass: every revealed letter must be reused
+		revealedLetterCount := make(map[string]int)
+		for i, status := range prev.Statuses {
+			if status == Miss || i >= len(prevLetters) {
+				continue
+			}
+			revealedLetterCount[prevLetters[i]]++
+		}
+		for i, status := range prev.Statuses {
+			if status != Present || i >= len(prevLetters) {
+				continue
+			}
+			letter := prevLetters[i]
+			if guessLetterCount[letter] < revealedLetterCount[letter] {
+				return &HardModeError{Letter: letter, Position: -1}
+			}
+		}
+	}
+
+	return nil
+}
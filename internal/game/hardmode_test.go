@@ -0,0 +1,102 @@
+package game
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestCheckHardMode(t *testing.T) {
+	// CRANE vs answer CRATE: C, R, A, E are Hit, N is Miss
+	// SPEED vs answer ERASE: S, E, E are Present
+	tests := []struct {
+		name     string
+		history  []GuessResult
+		guess    string
+		position int // expected HardModeError position, -2 for no error
+		letter   string
+	}{
+		{
+			name:     "no history",
+			history:  nil,
+			guess:    "BRAIN",
+			position: -2,
+		},
+		{
+			name:     "keeps hits",
+			history:  []GuessResult{EvaluateGuess("CRANE", "CRATE")},
+			guess:    "CRAVE",
+			position: -2,
+		},
+		{
+			name:     "moves a hit",
+			history:  []GuessResult{EvaluateGuess("CRANE", "CRATE")},
+			guess:    "CRASH",
+			position: 4,
+			letter:   "E",
+		},
+		{
+			name:     "reuses present letters",
+			history:  []GuessResult{EvaluateGuess("SPEED", "ERASE")},
+			guess:    "EASES",
+			position: -2,
+		},
+		{
+			name:     "drops a present letter",
+			history:  []GuessResult{EvaluateGuess("SPEED", "ERASE")},
+			guess:    "EERIE",
+			position: -1,
+			letter:   "S",
+		},
+		{
+			name:     "drops a duplicate present letter",
+			history:  []GuessResult{EvaluateGuess("SPEED", "ERASE")},
+			guess:    "STEAM",
+			position: -1,
+			letter:   "E",
+		},
+	}
+
+	for _, tt := range tests {
+		err := CheckHardMode(tt.guess, tt.history)
+		if tt.position == -2 {
+			if err != nil {
+				t.Errorf("%s: CheckHardMode(%s) error = %v, want nil", tt.name, tt.guess, err)
+			}
+			continue
+		}
+
+		var hardErr *HardModeError
+		if !errors.As(err, &hardErr) {
+			t.Errorf("%s: CheckHardMode(%s) error = %v, want HardModeError", tt.name, tt.guess, err)
+			continue
+		}
+		if hardErr.Position != tt.position || hardErr.Letter != tt.letter {
+			t.Errorf("%s: CheckHardMode(%s) = {%s, %d}, want {%s, %d}",
+				tt.name, tt.guess, hardErr.Letter, hardErr.Position, tt.letter, tt.position)
+		}
+	}
+}
+
+func TestHardModeGame(t *testing.T) {
+	game, _ := NewGameWithAnswer(6, "CRATE")
+	game.HardMode = true
+
+	if _, err := game.MakeGuess("CRANE"); err != nil {
+		t.Fatalf("MakeGuess() error = %v, want nil", err)
+	}
+
+	// Rejected guesses must not use up a round
+	if _, err := game.MakeGuess("BRAIN"); err == nil {
+		t.Error("MakeGuess(\"BRAIN\") in hard mode should return error")
+	}
+	if game.CurrentRound != 1 {
+		t.Errorf("After rejected guess, CurrentRound = %d, want 1", game.CurrentRound)
+	}
+
+	if _, err := game.MakeGuess("CRATE"); err != nil {
+		t.Errorf("MakeGuess() error = %v, want nil", err)
+	}
+	if game.Status != Won {
+		t.Errorf("After correct guess, Status = %v, want Won", game.Status)
+	}
+}
@@ -0,0 +1,136 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+)
+
+// MaxBoards is the largest number of boards in a multi-board game (Octordle)
+const MaxBoards = 8
+
+// MultiGame is a Dordle/Quordle/Octordle style game: every guess is played
+// on all boards at once, each board with its own answer
+type MultiGame struct {
+	Boards       []*Game
+	MaxRounds    int
+	WordLength   int
+	CurrentRound int
+	Guesses      []string // Every guess made, in order
+	Status       GameStatus
+}
+
+// NewMultiGame creates a game with numBoards boards and distinct random answers
+// The round budget is numBoards+5, shared by all boards
+func NewMultiGame(numBoards, wordLength int, wordList []string) (*MultiGame, error) {
+	if numBoards < 2 || numBoards > MaxBoards {
+		return nil, fmt.Errorf("number of boards must be between 2 and %d", MaxBoards)
+	}
+	if !ValidWordLength(wordLength) {
+		return nil, fmt.Errorf("word length must be between %d and %d", MinWordLength, MaxWordLength)
+	}
+	if len(wordList) == 0 {
+		return nil, errors.New("word list cannot be empty")
+	}
+
+	validWords := dedupeWords(FilterWords(wordList, wordLength))
+	if len(validWords) < numBoards {
+		return nil, fmt.Errorf("need at least %d distinct %d-letter words for %d boards", numBoards, wordLength, numBoards)
+	}
+
+	maxRounds := numBoards + 5
+	order := rand.Perm(len(validWords))
+	boards := make([]*Game, numBoards)
+	for i := range boards {
+		boards[i] = &Game{
+			Answer:       validWords[order[i]],
+			MaxRounds:    maxRounds,
+			WordLength:   wordLength,
+			WordList:     validWords,
+			CurrentRound: 0,
+			History:      []GuessResult{},
+			Status:       InProgress,
+		}
+	}
+
+	return &MultiGame{
+		Boards:       boards,
+		MaxRounds:    maxRounds,
+		WordLength:   wordLength,
+		CurrentRound: 0,
+		Guesses:      []string{},
+		Status:       InProgress,
+	}, nil
+}
+
+// MakeGuess plays a guess on every unsolved board
+// Returns one result per board; boards solved by an earlier guess get an
+// empty result. The guess is rejected on all boards if any board rejects it.
+func (m *MultiGame) MakeGuess(guess string) ([]GuessResult, error) {
+	if m.Status != InProgress {
+		return nil, ErrGameOver
+	}
+
+	// Validate against every unsolved board before changing any state
+	normalized := ""
+	for _, board := range m.Boards {
+		if board.IsGameOver() {
+			continue
+		}
+		checked, err := board.checkGuess(guess)
+		if err != nil {
+			return nil, err
+		}
+		normalized = checked
+	}
+
+	results := make([]GuessResult, len(m.Boards))
+	for i, board := range m.Boards {
+		if board.IsGameOver() {
+			continue
+		}
+		results[i] = board.applyGuess(normalized)
+	}
+
+	m.CurrentRound++
+	m.Guesses = append(m.Guesses, normalized)
+
+	// Won only when every board is solved
+	if m.SolvedCount() == len(m.Boards) {
+		m.Status = Won
+	} else if m.CurrentRound >= m.MaxRounds {
+		m.Status = Lost
+	}
+
+	return results, nil
+}
+
+// SolvedCount returns the number of boards whose answer has been found
+func (m *MultiGame) SolvedCount() int {
+	solved := 0
+	for _, board := range m.Boards {
+		if board.Status == Won {
+			solved++
+		}
+	}
+	return solved
+}
+
+// Answers returns the answer of every board, in board order
+func (m *MultiGame) Answers() []string {
+	answers := make([]string, len(m.Boards))
+	for i, board := range m.Boards {
+		answers[i] = board.Answer
+	}
+	return answers
+}
+
+// IsGameOver checks if the game has ended
+func (m *MultiGame) IsGameOver() bool {
+	return m.Status != InProgress
+}
+
+// GetStatus returns the current game status
+func (m *MultiGame) GetStatus() GameStatus {
+	return m.Status
+}
@@ -0,0 +1,88 @@
+package game
+
+import (
+	"testing"
+)
+
+func TestNewMultiGame(t *testing.T) {
+	wordList := []string{"APPLE", "BRAIN", "CRANE", "DREAM", "EARTH"}
+
+	m, err := NewMultiGame(4, 5, wordList)
+	if err != nil {
+		t.Fatalf("NewMultiGame() error = %v, want nil", err)
+	}
+
+	if m.MaxRounds != 9 {
+		t.Errorf("NewMultiGame() MaxRounds = %d, want 9", m.MaxRounds)
+	}
+
+	// Answers must be distinct
+	seen := make(map[string]bool)
+	for _, answer := range m.Answers() {
+		if seen[answer] {
+			t.Errorf("NewMultiGame() duplicate answer %s", answer)
+		}
+		seen[answer] = true
+	}
+
+	// Invalid board counts and too few words
+	for _, boards := range []int{1, MaxBoards + 1} {
+		if _, err := NewMultiGame(boards, 5, wordList); err == nil {
+			t.Errorf("NewMultiGame(%d, ...) should return error", boards)
+		}
+	}
+	if _, err := NewMultiGame(2, 5, []string{"APPLE", "APPLE"}); err == nil {
+		t.Error("NewMultiGame() with one distinct word should return error")
+	}
+}
+
+func TestMultiGameFlow(t *testing.T) {
+	m, _ := NewMultiGame(2, 5, []string{"APPLE", "BRAIN"})
+	answers := m.Answers()
+
+	// Invalid guesses are rejected on all boards
+	if _, err := m.MakeGuess("APP"); err == nil {
+		t.Error("MakeGuess(\"APP\") should return error")
+	}
+	if m.CurrentRound != 0 {
+		t.Errorf("After invalid guess, CurrentRound = %d, want 0", m.CurrentRound)
+	}
+
+	// Solving the first board leaves the game in progress
+	results, err := m.MakeGuess(answers[0])
+	if err != nil {
+		t.Fatalf("MakeGuess() error = %v, want nil", err)
+	}
+	if len(results) != 2 {
+		t.Fatalf("MakeGuess() returned %d results, want 2", len(results))
+	}
+	if FormatResult(results[0]) != "OOOOO" {
+		t.Errorf("Board 1 result = %s, want OOOOO", FormatResult(results[0]))
+	}
+	if m.Status != InProgress || m.SolvedCount() != 1 {
+		t.Errorf("After solving one board, Status = %v, SolvedCount = %d", m.Status, m.SolvedCount())
+	}
+
+	// Solved boards get empty results for later guesses
+	results, _ = m.MakeGuess(answers[1])
+	if len(results[0].Statuses) != 0 {
+		t.Errorf("Solved board result = %v, want empty", results[0].Statuses)
+	}
+	if m.Status != Won {
+		t.Errorf("After solving all boards, Status = %v, want Won", m.Status)
+	}
+}
+
+func TestMultiGameLoss(t *testing.T) {
+	m, _ := NewMultiGame(2, 5, []string{"APPLE", "BRAIN"})
+
+	for i := 0; i < m.MaxRounds; i++ {
+		if _, err := m.MakeGuess("CRANE"); err != nil {
+			t.Fatalf("MakeGuess() error = %v, want nil", err)
+		}
+	}
+
+	if m.Status != Lost {
+		t.Errorf("After %d rounds, Status = %v, want Lost", m.MaxRounds, m.Status)
+	}
+}
@@ -0,0 +1,113 @@
+package game
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// Supported word list languages
+// The language decides how words are upper-cased and which accented letters
+// are letters of their own that diacritic folding must keep.
+const (
+	LanguageEnglish = "en"
+	LanguageSpanish = "es"
+	LanguageGerman  = "de"
+	LanguageRussian = "ru"
+	LanguageTurkish = "tr"
+)
+
+// languageLetters lists the accented letters that are distinct letters of the
+// alphabet, e.g. Ñ is not an N in Spanish and Й is not an И in Russian
+var languageLetters = map[string]string{
+	LanguageEnglish: "",
+	LanguageSpanish: "Ñ",
+	LanguageGerman:  "ÄÖÜ",
+	LanguageRussian: "Й",
+	LanguageTurkish: "ÇĞİÖŞÜ",
+}
+
+// ValidLanguage checks if a language code is supported (empty means English)
+func ValidLanguage(language string) bool {
+	if language == "" {
+		return true
+	}
+	_, exists := languageLetters[language]
+	return exists
+}
+
+// Normalization holds the rules used to bring words to the canonical form
+// they are compared in. The zero value upper-cases with the default rules.
+type Normalization struct {
+	Language       string // Language code, e.g. "es"; empty means English
+	FoldDiacritics bool   // Strip accents, e.g. treat É as E
+}
+
+// Normalize returns the canonical form of a word:
+// 1. Trim spaces and compose accents (NFC), so É is one letter however it was typed
+// 2. Upper-case with the language's case rules (Turkish i becomes İ)
+// 3. Optionally strip accents from letters that are not letters of their own
+func (n Normalization) Normalize(word string) string {
+	word = norm.NFC.String(strings.TrimSpace(word))
+	if n.Language == LanguageTurkish {
+		word = strings.ToUpperSpecial(unicode.TurkishCase, word)
+	} else {
+		word = strings.ToUpper(word)
+	}
+
+	if !n.FoldDiacritics {
+		return word
+	}
+
+	keep := languageLetters[n.Language]
+	var sb strings.Builder
+	for _, letter := range Letters(word) {
+		if strings.Contains(keep, letter) {
+			sb.WriteString(letter)
+			continue
+		}
+		for _, ch := range norm.NFD.String(letter) {
+			if !unicode.Is(unicode.Mn, ch) {
+				sb.WriteRune(ch)
+			}
+		}
+	}
+	return norm.NFC.String(sb.String())
+}
+
+// NormalizeWords normalizes every word in a list, dropping empty lines
+func (n Normalization) NormalizeWords(words []string) []string {
+	normalized := make([]string, 0, len(words))
+	for _, word := range words {
+		if word = n.Normalize(word); word != "" {
+			normalized = append(normalized, word)
+		}
+	}
+	return normalized
+}
+
+// NormalizeWord normalizes a word with the default rules
+func NormalizeWord(word string) string {
+	return Normalization{}.Normalize(word)
+}
+
+// Letters splits a word into letters: each base character together with the
+// combining marks that follow it, so a decomposed É still counts as one letter
+func Letters(word string) []string {
+	letters := []string{}
+	start := -1
+	for i, ch := range word {
+		if start >= 0 && unicode.Is(unicode.M, ch) {
+			continue
+		}
+		if start >= 0 {
+			letters = append(letters, word[start:i])
+		}
+		start = i
+	}
+	if start >= 0 {
+		letters = append(letters, word[start:])
+	}
+	return letters
+}
@@ -0,0 +1,57 @@
+package game
+
+import "testing"
+
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		name     string
+		n        Normalization
+		word     string
+		expected string
+	}{
+		{"default", Normalization{}, " crane ", "CRANE"},
+		{"composes accents", Normalization{}, "árbol", "ÁRBOL"},
+		{"keeps accents", Normalization{Language: LanguageSpanish}, "árbol", "ÁRBOL"},
+		{"folds accents", Normalization{Language: LanguageSpanish, FoldDiacritics: true}, "árbol", "ARBOL"},
+		{"keeps Ñ", Normalization{Language: LanguageSpanish, FoldDiacritics: true}, "niño", "NIÑO"},
+		{"keeps umlauts", Normalization{Language: LanguageGerman, FoldDiacritics: true}, "café", "CAFE"},
+		{"german umlaut", Normalization{Language: LanguageGerman, FoldDiacritics: true}, "übung", "ÜBUNG"},
+		{"folds Ё", Normalization{Language: LanguageRussian, FoldDiacritics: true}, "ёлка", "ЕЛКА"},
+		{"keeps Й", Normalization{Language: LanguageRussian, FoldDiacritics: true}, "чайка", "ЧАЙКА"},
+		{"turkish dotted i", Normalization{Language: LanguageTurkish}, "kitap", "KİTAP"},
+		{"english i", Normalization{}, "kitap", "KITAP"},
+	}
+
+	for _, tt := range tests {
+		if result := tt.n.Normalize(tt.word); result != tt.expected {
+			t.Errorf("%s: Normalize(%q) = %q, want %q", tt.name, tt.word, result, tt.expected)
+		}
+	}
+}
+
+func TestLetters(t *testing.T) {
+	letters := Letters("ÁRBOL")
+	if len(letters) != 5 || letters[0] != "Á" {
+		t.Errorf("Letters(%q) = %q, want 5 letters starting with %q", "ÁRBOL", letters, "Á")
+	}
+}
+
+func TestNormalizedGame(t *testing.T) {
+	game, _ := NewGameWithAnswer(6, "ÁRBOL")
+	if game.WordLength != 5 {
+		t.Fatalf("WordLength = %d, want 5", game.WordLength)
+	}
+
+	game.Normalization = Normalization{Language: LanguageSpanish}
+	result, err := game.MakeGuess("árbol")
+	if err != nil {
+		t.Fatalf("MakeGuess() error = %v, want nil", err)
+	}
+	if FormatResult(result) != "OOOOO" || game.Status != Won {
+		t.Errorf("MakeGuess(\"árbol\") = %s, status %v; want OOOOO, Won", FormatResult(result), game.Status)
+	}
+
+	if !ValidLanguage("") || !ValidLanguage(LanguageRussian) || ValidLanguage("xx") {
+		t.Error("ValidLanguage() reports wrong supported languages")
+	}
+}
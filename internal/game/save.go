@@ -0,0 +1,187 @@
+package game
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+)
+
+// SaveFormatVersion is the version of the JSON format written by MarshalJSON
+// Bump it when the format changes incompatibly; UnmarshalJSON rejects newer versions
+const SaveFormatVersion = 1
+
+// savedGame is the on-disk form of a Game
+// The word list and dictionary are not saved: they come from the configuration.
+type savedGame struct {
+	Version        int           `json:"version"`
+	Answer         string        `json:"answer,omitempty"` // Obfuscated
+	MaxRounds      int           `json:"max_rounds"`
+	WordLength     int           `json:"word_length"`
+	CurrentRound   int           `json:"current_round"`
+	History        []savedResult `json:"history"`
+	Status         string        `json:"status"`
+	HardMode       bool          `json:"hard_mode,omitempty"`
+	PuzzleNumber   int           `json:"puzzle_number,omitempty"`
+	PuzzleDate     string        `json:"puzzle_date,omitempty"`
+	Language       string        `json:"language,omitempty"`
+	FoldDiacritics bool          `json:"fold_diacritics,omitempty"`
+	Adversarial    bool          `json:"adversarial,omitempty"`
+	Candidates     []string      `json:"candidates,omitempty"` // Obfuscated
+}
+
+// savedResult is the on-disk form of a GuessResult
+type savedResult struct {
+	Guess  string `json:"guess"`
+	Result string `json:"result"` // FormatResult pattern, e.g. "O?__O"
+}
+
+// Saved game status names
+var statusNames = map[GameStatus]string{
+	InProgress: "in_progress",
+	Won:        "won",
+	Lost:       "lost",
+}
+
+// obfuscationKey scrambles answers on disk so a saved game does not spoil
+// the answer at a glance. It is not meant to be secure.
+var obfuscationKey = []byte("wordle-saved-game")
+
+// obfuscate XORs a word with obfuscationKey and base64-encodes the result
+func obfuscate(word string) string {
+	data := []byte(word)
+	for i := range data {
+		data[i] ^= obfuscationKey[i%len(obfuscationKey)]
+	}
+	return base64.StdEncoding.EncodeToString(data)
+}
+
+// deobfuscate reverses obfuscate
+func deobfuscate(encoded string) (string, error) {
+	data, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return "", err
+	}
+	for i := range data {
+		data[i] ^= obfuscationKey[i%len(obfuscationKey)]
+	}
+	return string(data), nil
+}
+
+// MarshalJSON encodes the game in the versioned save format
+func (g *Game) MarshalJSON() ([]byte, error) {
+	saved := savedGame{
+		Version:        SaveFormatVersion,
+		MaxRounds:      g.MaxRounds,
+		WordLength:     g.WordLength,
+		CurrentRound:   g.CurrentRound,
+		History:        make([]savedResult, len(g.History)),
+		Status:         statusNames[g.Status],
+		HardMode:       g.HardMode,
+		PuzzleNumber:   g.PuzzleNumber,
+		PuzzleDate:     g.PuzzleDate,
+		Language:       g.Normalization.Language,
+		FoldDiacritics: g.Normalization.FoldDiacritics,
+		Adversarial:    g.Adversarial,
+	}
+	if g.Answer != "" {
+		saved.Answer = obfuscate(g.Answer)
+	}
+	for i, result := range g.History {
+		saved.History[i] = savedResult{Guess: result.Guess, Result: FormatResult(result)}
+	}
+	for _, candidate := range g.Candidates {
+		saved.Candidates = append(saved.Candidates, obfuscate(candidate))
+	}
+	return json.Marshal(saved)
+}
+
+// UnmarshalJSON decodes a game saved by MarshalJSON
+// The caller must restore WordList and Dictionary from the configuration.
+func (g *Game) UnmarshalJSON(data []byte) error {
+	var saved savedGame
+	if err := json.Unmarshal(data, &saved); err != nil {
+		return err
+	}
+	if saved.Version < 1 || saved.Version > SaveFormatVersion {
+		return fmt.Errorf("unsupported saved game version %d", saved.Version)
+	}
+
+	restored := Game{
+		MaxRounds:    saved.MaxRounds,
+		WordLength:   saved.WordLength,
+		CurrentRound: saved.CurrentRound,
+		History:      make([]GuessResult, len(saved.History)),
+		HardMode:     saved.HardMode,
+		PuzzleNumber: saved.PuzzleNumber,
+		PuzzleDate:   saved.PuzzleDate,
+		Normalization: Normalization{
+			Language:       saved.Language,
+			FoldDiacritics: saved.FoldDiacritics,
+		},
+		Adversarial: saved.Adversarial,
+	}
+
+	status, ok := parseStatus(saved.Status)
+	if !ok {
+		return fmt.Errorf("invalid saved game status %q", saved.Status)
+	}
+	restored.Status = status
+
+	if saved.Answer != "" {
+		answer, err := deobfuscate(saved.Answer)
+		if err != nil {
+			return fmt.Errorf("invalid saved answer: %w", err)
+		}
+		restored.Answer = answer
+	}
+	if restored.Answer == "" && !restored.Adversarial {
+		return fmt.Errorf("saved game has no answer")
+	}
+
+	for i, result := range saved.History {
+		statuses, err := parseResult(result.Result)
+		if err != nil {
+			return err
+		}
+		restored.History[i] = GuessResult{Guess: result.Guess, Statuses: statuses}
+	}
+
+	for _, encoded := range saved.Candidates {
+		candidate, err := deobfuscate(encoded)
+		if err != nil {
+			return fmt.Errorf("invalid saved candidate: %w", err)
+		}
+		restored.Candidates = append(restored.Candidates, candidate)
+	}
+
+	*g = restored
+	return nil
+}
+
+// parseStatus converts a saved status name back to a GameStatus
+func parseStatus(name string) (GameStatus, bool) {
+	for status, statusName := range statusNames {
+		if statusName == name {
+			return status, true
+		}
+	}
+	return InProgress, false
+}
+
+// parseResult converts a FormatResult pattern back to letter statuses
+func parseResult(pattern string) ([]LetterStatus, error) {
+	statuses := []LetterStatus{}
+	for _, ch := range pattern {
+		switch ch {
+		case 'O':
+			statuses = append(statuses, Hit)
+		case '?':
+			statuses = append(statuses, Present)
+		case '_':
+			statuses = append(statuses, Miss)
+		default:
+			return nil, fmt.Errorf("invalid saved result %q", pattern)
+		}
+	}
+	return statuses, nil
+}
@@ -0,0 +1,58 @@
+package game
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestSaveAndLoadGame(t *testing.T) {
+	game, _ := NewGameWithAnswer(6, "CRATE")
+	game.HardMode = true
+	game.MakeGuess("CRANE")
+
+	data, err := json.Marshal(game)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	if strings.Contains(string(data), "CRATE") {
+		t.Errorf("saved game %s contains the answer in plain text", data)
+	}
+
+	var loaded Game
+	if err := json.Unmarshal(data, &loaded); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if loaded.Answer != "CRATE" || loaded.CurrentRound != 1 || !loaded.HardMode || loaded.Status != InProgress {
+		t.Errorf("loaded game = %+v, want CRATE after 1 round in hard mode", loaded)
+	}
+	if len(loaded.History) != 1 || FormatResult(loaded.History[0]) != "OOO_O" {
+		t.Errorf("loaded history = %v, want CRANE OOO_O", loaded.History)
+	}
+
+	// The loaded game can be played on
+	if _, err := loaded.MakeGuess("CRATE"); err != nil || loaded.Status != Won {
+		t.Errorf("MakeGuess() on loaded game = %v, status %v; want Won", err, loaded.Status)
+	}
+}
+
+func TestSaveAndLoadAdversarialGame(t *testing.T) {
+	game, _ := NewAdversarialGame(5, []string{"CRATE", "CRANE", "BRAIN"})
+	game.MakeGuess("BRAIN")
+
+	data, _ := json.Marshal(game)
+	var loaded Game
+	if err := json.Unmarshal(data, &loaded); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if !loaded.Adversarial || len(loaded.Candidates) != len(game.Candidates) {
+		t.Errorf("loaded candidates = %v, want %v", loaded.Candidates, game.Candidates)
+	}
+}
+
+func TestLoadGameVersion(t *testing.T) {
+	var loaded Game
+	if err := json.Unmarshal([]byte(`{"version": 99, "status": "in_progress"}`), &loaded); err == nil {
+		t.Error("Unmarshal() of a newer version should return error")
+	}
+}
@@ -3,6 +3,8 @@ package game
 import (
 	"strings"
 	"unicode"
+
+	"golang.org/x/text/unicode/norm"
 )
 
 // LetterStatus represents the status of a letter in the guess
@@ -23,12 +25,35 @@ type GuessResult struct {
 	Statuses []LetterStatus
 }
 
+// Supported word lengths
+const (
+	// MinWordLength is the shortest word length a game can be played with
+	MinWordLength = 4
+	// MaxWordLength is the longest word length a game can be played with
+	MaxWordLength = 11
+	// DefaultWordLength is the classic Wordle word length
+	DefaultWordLength = 5
+)
+
+// ValidWordLength checks if length is within the supported range
+func ValidWordLength(length int) bool {
+	return length >= MinWordLength && length <= MaxWordLength
+}
+
 // ValidateWord checks if a word is valid (5 letters, alphabetic only)
 func ValidateWord(word string) bool {
-	if len(word) != 5 {
+	return ValidateWordLength(word, DefaultWordLength)
+}
+
+// ValidateWordLength checks if a word is valid (length letters, alphabetic only)
+// Letters are counted as characters, not bytes, so accented letters count once
+func ValidateWordLength(word string, length int) bool {
+	letters := Letters(norm.NFC.String(word))
+	if len(letters) != length {
 		return false
 	}
-	for _, ch := range word {
+	for _, letter := range letters {
+		ch := []rune(letter)[0]
 		if !unicode.IsLetter(ch) {
 			return false
 		}
@@ -36,44 +61,86 @@ func ValidateWord(word string) bool {
 	return true
 }
 
+// FilterWords returns the normalized words from wordList that are valid for the given length
+func FilterWords(wordList []string, length int) []string {
+	validWords := []string{}
+	for _, word := range wordList {
+		word = NormalizeWord(word)
+		if ValidateWordLength(word, length) {
+			validWords = append(validWords, word)
+		}
+	}
+	return validWords
+}
+
+// Dictionary is a set of upper-cased words accepted as guesses
+type Dictionary map[string]struct{}
+
+// NewDictionary builds a dictionary from one or more word lists
+func NewDictionary(wordLists ...[]string) Dictionary {
+	dict := make(Dictionary)
+	for _, wordList := range wordLists {
+		for _, word := range wordList {
+			word = NormalizeWord(word)
+			if word != "" {
+				dict[word] = struct{}{}
+			}
+		}
+	}
+	return dict
+}
+
+// Contains checks if a word is in the dictionary (case-insensitive)
+func (d Dictionary) Contains(word string) bool {
+	_, exists := d[NormalizeWord(word)]
+	return exists
+}
+
 // EvaluateGuess compares the guess with the answer and returns the result
 // This implements the exact Wordle scoring logic:
 // 1. First pass: mark all exact matches (Hit)
 // 2. Second pass: mark Present for remaining letters that exist in answer
 // 3. Handle duplicate letters correctly
 func EvaluateGuess(guess, answer string) GuessResult {
-	guess = strings.ToUpper(guess)
-	answer = strings.ToUpper(answer)
+	guess = NormalizeWord(guess)
+	answer = NormalizeWord(answer)
+
+	// Compare letter by letter rather than byte by byte
+	guessLetters := Letters(guess)
+	answerLetters := Letters(answer)
+
+	// Guess and answer are expected to have the same length; only the
+	// common prefix is compared position by position
+	length := min(len(guessLetters), len(answerLetters))
 
 	result := GuessResult{
 		Guess:    guess,
-		Statuses: make([]LetterStatus, 5),
+		Statuses: make([]LetterStatus, len(guessLetters)),
 	}
 
 	// Count available letters in answer (excluding exact matches)
-	answerLetterCount := make(map[rune]int)
-	for _, ch := range answer {
-		answerLetterCount[ch]++
+	answerLetterCount := make(map[string]int)
+	for _, letter := range answerLetters {
+		answerLetterCount[letter]++
 	}
 
 	// First pass: identify all exact matches (Hit)
-	for i := 0; i < 5; i++ {
-		if guess[i] == answer[i] {
+	for i := 0; i < length; i++ {
+		if guessLetters[i] == answerLetters[i] {
 			result.Statuses[i] = Hit
-			answerLetterCount[rune(guess[i])]--
+			answerLetterCount[guessLetters[i]]--
 		}
 	}
 
 	// Second pass: identify Present letters
-	for i := 0; i < 5; i++ {
+	for i, letter := range guessLetters {
 		if result.Statuses[i] == Hit {
 			continue
 		}
 
-		ch := rune(guess[i])
-		if count, exists := answerLetterCount[ch]; exists && count > 0 {
+		if count, exists := answerLetterCount[letter]; exists && count > 0 {
 			result.Statuses[i] = Present
-			answerLetterCount[ch]--
+			answerLetterCount[letter]--
 		} else {
 			result.Statuses[i] = Miss
 		}
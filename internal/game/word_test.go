@@ -25,6 +25,42 @@ func TestValidateWord(t *testing.T) {
 	}
 }
 
+func TestValidateWordLength(t *testing.T) {
+	tests := []struct {
+		word   string
+		length int
+		valid  bool
+	}{
+		{"TREE", 4, true},
+		{"PLANET", 6, true},
+		{"READING", 7, true},
+		{"programmers", 11, true},
+		{"PLANET", 5, false}, // wrong length
+		{"PLAN3T", 6, false}, // contains number
+	}
+
+	for _, tt := range tests {
+		result := ValidateWordLength(tt.word, tt.length)
+		if result != tt.valid {
+			t.Errorf("ValidateWordLength(%s, %d) = %v, want %v", tt.word, tt.length, result, tt.valid)
+		}
+	}
+}
+
+func TestFilterWords(t *testing.T) {
+	words := FilterWords([]string{"apple", " PLANET ", "garden", "TREE", "pl4net"}, 6)
+	expected := []string{"PLANET", "GARDEN"}
+
+	if len(words) != len(expected) {
+		t.Fatalf("FilterWords() = %v, want %v", words, expected)
+	}
+	for i := range expected {
+		if words[i] != expected[i] {
+			t.Errorf("FilterWords()[%d] = %s, want %s", i, words[i], expected[i])
+		}
+	}
+}
+
 func TestEvaluateGuess(t *testing.T) {
 	tests := []struct {
 		guess    string
@@ -64,10 +100,29 @@ func TestEvaluateGuess(t *testing.T) {
 			answer:   "ERASE",
 			expected: []LetterStatus{Miss, Present, Miss, Hit, Hit},
 		},
+		{
+			// Test longer words: PLANET vs PLANTS
+			// Exact matches: P, L, A, N. Remaining: T(1), S(1)
+			// E-Miss, T-Present
+			guess:    "PLANET",
+			answer:   "PLANTS",
+			expected: []LetterStatus{Hit, Hit, Hit, Hit, Miss, Present},
+		},
+		{
+			// Test shorter words: TREE vs REST
+			guess:    "TREE",
+			answer:   "REST",
+			expected: []LetterStatus{Present, Present, Present, Miss},
+		},
 	}
 
 	for _, tt := range tests {
 		result := EvaluateGuess(tt.guess, tt.answer)
+		if len(result.Statuses) != len(tt.expected) {
+			t.Errorf("EvaluateGuess(%s, %s) returned %d statuses, want %d",
+				tt.guess, tt.answer, len(result.Statuses), len(tt.expected))
+			continue
+		}
 		for i, status := range result.Statuses {
 			if status != tt.expected[i] {
 				t.Errorf("EvaluateGuess(%s, %s)[%d] = %v, want %v",
@@ -90,3 +145,48 @@ func TestFormatResult(t *testing.T) {
 		t.Errorf("FormatResult() = %s, want %s", formatted, expected)
 	}
 }
+
+func TestValidateWordUnicode(t *testing.T) {
+	tests := []struct {
+		word   string
+		length int
+		valid  bool
+	}{
+		{"ÁRBOL", 5, true},
+		{"ÜBUNG", 5, true},
+		{"СЛОВО", 5, true},
+		{"A\u0301RBOL", 5, true}, // decomposed Á counts as one letter
+		{"ÁRBOL", 6, false},
+		{"\u0301ARBOL", 5, false}, // leading combining mark
+	}
+
+	for _, tt := range tests {
+		result := ValidateWordLength(tt.word, tt.length)
+		if result != tt.valid {
+			t.Errorf("ValidateWordLength(%q, %d) = %v, want %v", tt.word, tt.length, result, tt.valid)
+		}
+	}
+}
+
+func TestEvaluateGuessUnicode(t *testing.T) {
+	tests := []struct {
+		guess    string
+		answer   string
+		expected string
+	}{
+		{"ÁRBOL", "ÁRBOL", "OOOOO"},
+		{"ARBOL", "ÁRBOL", "_OOOO"}, // Á and A are different letters
+		{"árbol", "ÁRBOL", "OOOOO"},
+		{"слово", "СЛОВО", "OOOOO"},
+		{"ВОЛОС", "СЛОВО", "?????"},
+		{"GRÜßE", "GRÜßE", "OOOOO"},
+	}
+
+	for _, tt := range tests {
+		result := EvaluateGuess(tt.guess, tt.answer)
+		formatted := FormatResult(result)
+		if formatted != tt.expected {
+			t.Errorf("EvaluateGuess(%s, %s) = %s, want %s", tt.guess, tt.answer, formatted, tt.expected)
+		}
+	}
+}
@@ -0,0 +1,178 @@
+package solver
+
+import (
+	"fmt"
+	"maps"
+	"math"
+	"slices"
+	"sort"
+
+	"github.com/admin/wordle/internal/game"
+)
+
+// Strategy selects how candidate guesses are ranked
+type Strategy int
+
+const (
+	// Entropy ranks guesses by the expected information of their feedback
+	Entropy Strategy = iota
+	// WorstCase ranks guesses by the size of the largest group of answers
+	// left after their feedback (minimax)
+	WorstCase
+)
+
+// ParseStrategy parses a strategy name ("entropy" or "worst_case")
+// An empty name selects Entropy
+func ParseStrategy(name string) (Strategy, error) {
+	switch name {
+	case "", "entropy":
+		return Entropy, nil
+	case "worst_case", "worst-case", "minimax":
+		return WorstCase, nil
+	default:
+		return Entropy, fmt.Errorf("unknown strategy: %s", name)
+	}
+}
+
+// String returns the strategy name
+func (s Strategy) String() string {
+	if s == WorstCase {
+		return "worst_case"
+	}
+	return "entropy"
+}
+
+// MaxEvaluations bounds the work of one Suggest call, as guess-candidate
+// pairs scored: with many candidates, fewer guesses are considered
+const MaxEvaluations = 250_000
+
+// Suggestion is a guess scored against the remaining candidates
+type Suggestion struct {
+	Word      string
+	Entropy   float64 // Expected information in bits; higher is better
+	WorstCase int     // Candidates left in the worst case; lower is better
+	Candidate bool    // The word could still be the answer
+}
+
+// Filter returns the candidates consistent with every result in history
+func Filter(candidates []string, history []game.GuessResult) []string {
+	return game.ConsistentWords(candidates, history)
+}
+
+// Rank scores every guess against the candidates and returns the best n
+// (all of them when n <= 0). Ties go to guesses that could be the answer,
+// then to alphabetical order, so the ranking is deterministic.
+func Rank(guesses, candidates []string, strategy Strategy, n int) []Suggestion {
+	isCandidate := make(map[string]bool, len(candidates))
+	for _, candidate := range candidates {
+		isCandidate[candidate] = true
+	}
+
+	suggestions := make([]Suggestion, 0, len(guesses))
+	for _, guess := range guesses {
+		suggestion := score(guess, candidates)
+		suggestion.Candidate = isCandidate[guess]
+		suggestions = append(suggestions, suggestion)
+	}
+
+	sort.Slice(suggestions, func(i, j int) bool {
+		return better(suggestions[i], suggestions[j], strategy)
+	})
+
+	if n > 0 && len(suggestions) > n {
+		suggestions = suggestions[:n]
+	}
+	return suggestions
+}
+
+// score buckets the candidates by the feedback a guess would get
+func score(guess string, candidates []string) Suggestion {
+	buckets := make(map[int]int)
+	for _, candidate := range candidates {
+		buckets[feedback(game.EvaluateGuess(guess, candidate))]++
+	}
+
+	suggestion := Suggestion{Word: guess}
+	total := float64(len(candidates))
+	for _, size := range buckets {
+		p := float64(size) / total
+		suggestion.Entropy -= p * math.Log2(p)
+		suggestion.WorstCase = max(suggestion.WorstCase, size)
+	}
+	return suggestion
+}
+
+// feedback encodes a result's letter statuses as one number, base 3
+func feedback(result game.GuessResult) int {
+	code := 0
+	for _, status := range result.Statuses {
+		code = code*3 + int(status)
+	}
+	return code
+}
+
+// better reports whether suggestion a ranks above suggestion b
+func better(a, b Suggestion, strategy Strategy) bool {
+	if strategy == WorstCase {
+		if a.WorstCase != b.WorstCase {
+			return a.WorstCase < b.WorstCase
+		}
+		if a.Entropy != b.Entropy {
+			return a.Entropy > b.Entropy
+		}
+	} else {
+		if a.Entropy != b.Entropy {
+			return a.Entropy > b.Entropy
+		}
+		if a.WorstCase != b.WorstCase {
+			return a.WorstCase < b.WorstCase
+		}
+	}
+	if a.Candidate != b.Candidate {
+		return a.Candidate
+	}
+	return a.Word < b.Word
+}
+
+// Suggest returns the best n next guesses for a game in progress
+// Guesses come from the word list and the allowed-guess dictionary; in hard
+// mode only guesses that use every revealed hint are considered. At most
+// MaxEvaluations pairs are scored, taking candidates first, then the word
+// list, then the dictionary in alphabetical order.
+func Suggest(g *game.Game, strategy Strategy, n int) []Suggestion {
+	candidates := g.RemainingCandidates()
+	if len(candidates) == 0 || g.IsGameOver() {
+		return []Suggestion{}
+	}
+
+	limit := max(1, MaxEvaluations/len(candidates))
+	guesses := []string{}
+	seen := make(map[string]bool)
+	addGuess := func(word string) {
+		if len(guesses) >= limit || seen[word] || !game.ValidateWordLength(word, g.WordLength) {
+			return
+		}
+		seen[word] = true
+		if g.HardMode && game.CheckHardMode(word, g.History) != nil {
+			return
+		}
+		guesses = append(guesses, word)
+	}
+
+	for _, word := range candidates {
+		addGuess(word)
+	}
+	// Once few candidates are left, guessing one of them is never worse
+	if len(candidates) > 2 {
+		for _, word := range g.WordList {
+			addGuess(word)
+		}
+		if len(guesses) < limit {
+			for _, word := range slices.Sorted(maps.Keys(g.Dictionary)) {
+				addGuess(word)
+			}
+		}
+	}
+
+	return Rank(guesses, candidates, strategy, n)
+}
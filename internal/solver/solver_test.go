@@ -0,0 +1,103 @@
+package solver
+
+import (
+	"testing"
+
+	"github.com/admin/wordle/internal/game"
+)
+
+func TestFilter(t *testing.T) {
+	words := []string{"CRATE", "CRANE", "GRACE", "TRACE", "BRAIN"}
+	history := []game.GuessResult{game.EvaluateGuess("CRANE", "CRATE")}
+
+	candidates := Filter(words, history)
+	if len(candidates) != 1 || candidates[0] != "CRATE" {
+		t.Errorf("Filter() = %v, want [CRATE]", candidates)
+	}
+
+	if all := Filter(words, nil); len(all) != len(words) {
+		t.Errorf("Filter() with no history = %v, want all words", all)
+	}
+}
+
+func TestRank(t *testing.T) {
+	candidates := []string{"BATCH", "CATCH", "HATCH", "LATCH", "MATCH", "PATCH", "WATCH"}
+	guesses := append([]string{"CHAMP", "BLIMP"}, candidates...)
+
+	// CHAMP and BLIMP split the candidates better than any candidate does
+	entropy := Rank(guesses, candidates, Entropy, 3)
+	if len(entropy) != 3 {
+		t.Fatalf("Rank() returned %d suggestions, want 3", len(entropy))
+	}
+	if entropy[0].Candidate || entropy[1].Candidate {
+		t.Errorf("Rank(Entropy) best = %v, want CHAMP and BLIMP first", entropy[:2])
+	}
+	for i := 1; i < len(entropy); i++ {
+		if entropy[i].Entropy > entropy[i-1].Entropy {
+			t.Errorf("Rank(Entropy) not sorted: %v", entropy)
+		}
+	}
+
+	worst := Rank(guesses, candidates, WorstCase, 0)
+	if len(worst) != len(guesses) {
+		t.Fatalf("Rank() with n = 0 returned %d suggestions, want %d", len(worst), len(guesses))
+	}
+	for i := 1; i < len(worst); i++ {
+		if worst[i].WorstCase < worst[i-1].WorstCase {
+			t.Errorf("Rank(WorstCase) not sorted: %v", worst)
+		}
+	}
+	if worst[0].Candidate {
+		t.Errorf("Rank(WorstCase) best = %s, want a non-candidate splitter", worst[0].Word)
+	}
+}
+
+func TestSuggest(t *testing.T) {
+	g, _ := game.NewGame(6, 5, []string{"CRATE", "CRANE", "GRACE", "TRACE", "BRAIN"})
+	g.Answer = "CRATE"
+	g.MakeGuess("CRANE")
+
+	suggestions := Suggest(g, Entropy, 5)
+	if len(suggestions) != 1 || suggestions[0].Word != "CRATE" || !suggestions[0].Candidate {
+		t.Errorf("Suggest() = %v, want only CRATE", suggestions)
+	}
+
+	g.MakeGuess("CRATE")
+	if suggestions := Suggest(g, Entropy, 5); len(suggestions) != 0 {
+		t.Errorf("Suggest() after game over = %v, want none", suggestions)
+	}
+}
+
+func TestSuggestBoundsWork(t *testing.T) {
+	// 26 * 26 words, so the candidates alone exceed the evaluation budget
+	var words []string
+	for a := 'A'; a <= 'Z'; a++ {
+		for b := 'A'; b <= 'Z'; b++ {
+			words = append(words, string([]rune{a, b, 'X', 'Y', 'Z'}))
+		}
+	}
+	g, _ := game.NewGame(6, 5, words)
+	g.Dictionary = game.NewDictionary([]string{"QUICK", "JUMPS"}, words)
+
+	suggestions := Suggest(g, Entropy, 0)
+	if want := MaxEvaluations / len(words); len(suggestions) != want {
+		t.Errorf("Suggest() scored %d guesses against %d candidates, want %d", len(suggestions), len(words), want)
+	}
+	for _, suggestion := range suggestions {
+		if !suggestion.Candidate {
+			t.Errorf("Suggest() scored %s before the remaining candidates", suggestion.Word)
+		}
+	}
+}
+
+func TestParseStrategy(t *testing.T) {
+	if s, err := ParseStrategy(""); err != nil || s != Entropy {
+		t.Errorf("ParseStrategy(\"\") = %v, %v; want Entropy", s, err)
+	}
+	if s, err := ParseStrategy("worst_case"); err != nil || s != WorstCase {
+		t.Errorf("ParseStrategy(\"worst_case\") = %v, %v; want WorstCase", s, err)
+	}
+	if _, err := ParseStrategy("random"); err == nil {
+		t.Error("ParseStrategy(\"random\") should return error")
+	}
+}
@@ -0,0 +1,13 @@
+package api
+
+import _ "embed"
+
+// APIVersionPrefix is the path the current API is served under
+// The same routes are also served without it, for clients that predate it.
+const APIVersionPrefix = "/v1"
+
+// OpenAPISpec is the OpenAPI 3 document describing the API, served at
+// GET /v1/openapi.json
+//
+//go:embed openapi.json
+var OpenAPISpec []byte
@@ -0,0 +1,272 @@
+package api
+
+import (
+	"encoding/json"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// schema is the part of an OpenAPI schema object the tests compare
+type schema struct {
+	Ref                  string             `json:"$ref"`
+	Type                 string             `json:"type"`
+	Format               string             `json:"format"`
+	Items                *schema            `json:"items"`
+	AdditionalProperties *schema            `json:"additionalProperties"`
+	Properties           map[string]*schema `json:"properties"`
+	Required             []string           `json:"required"`
+	Enum                 []string           `json:"enum"`
+}
+
+// describe renders a schema as a short type expression, e.g. "[]#GuessResponse"
+func (s *schema) describe() string {
+	switch {
+	case s == nil:
+		return "<missing>"
+	case s.Ref != "":
+		return "#" + strings.TrimPrefix(s.Ref, "#/components/schemas/")
+	case s.Type == "array":
+		return "[]" + s.Items.describe()
+	case s.Type == "object" && s.AdditionalProperties != nil:
+		return "map[string]" + s.AdditionalProperties.describe()
+	case s.Format == "int64":
+		return "integer/int64"
+	default:
+		return s.Type
+	}
+}
+
+// describeGoType renders a Go type the way describe renders its schema
+func describeGoType(t *testing.T, expr ast.Expr) string {
+	switch e := expr.(type) {
+	case *ast.StarExpr:
+		return describeGoType(t, e.X)
+	case *ast.ArrayType:
+		return "[]" + describeGoType(t, e.Elt)
+	case *ast.MapType:
+		return "map[string]" + describeGoType(t, e.Value)
+	case *ast.Ident:
+		switch e.Name {
+		case "string":
+			return "string"
+		case "bool":
+			return "boolean"
+		case "int":
+			return "integer"
+		case "int64":
+			return "integer/int64"
+		case "float64":
+			return "number"
+		}
+		return "#" + e.Name
+	}
+	t.Fatalf("unsupported field type %T", expr)
+	return ""
+}
+
+// parsePackage parses the package's non-test files
+func parsePackage(t *testing.T) []*ast.File {
+	t.Helper()
+	paths, err := filepath.Glob("*.go")
+	if err != nil {
+		t.Fatalf("Glob() error = %v", err)
+	}
+
+	fset := token.NewFileSet()
+	var files []*ast.File
+	for _, path := range paths {
+		if strings.HasSuffix(path, "_test.go") {
+			continue
+		}
+		file, err := parser.ParseFile(fset, path, nil, 0)
+		if err != nil {
+			t.Fatalf("ParseFile(%s) error = %v", path, err)
+		}
+		files = append(files, file)
+	}
+	return files
+}
+
+// goStructs returns the package's struct types by name
+func goStructs(t *testing.T) map[string]*ast.StructType {
+	structs := make(map[string]*ast.StructType)
+	for _, file := range parsePackage(t) {
+		ast.Inspect(file, func(n ast.Node) bool {
+			if spec, ok := n.(*ast.TypeSpec); ok {
+				if st, ok := spec.Type.(*ast.StructType); ok {
+					structs[spec.Name.Name] = st
+				}
+			}
+			return true
+		})
+	}
+	return structs
+}
+
+// goErrorCodes returns the values of the package's Code constants, sorted
+func goErrorCodes(t *testing.T) []string {
+	var codes []string
+	for _, file := range parsePackage(t) {
+		ast.Inspect(file, func(n ast.Node) bool {
+			spec, ok := n.(*ast.ValueSpec)
+			if !ok {
+				return true
+			}
+			for i, name := range spec.Names {
+				if !strings.HasPrefix(name.Name, "Code") || i >= len(spec.Values) {
+					continue
+				}
+				if lit, ok := spec.Values[i].(*ast.BasicLit); ok {
+					value, _ := strconv.Unquote(lit.Value)
+					codes = append(codes, value)
+				}
+			}
+			return true
+		})
+	}
+	slices.Sort(codes)
+	return codes
+}
+
+// loadSpec parses the schemas out of openapi.json
+func loadSpec(t *testing.T) map[string]*schema {
+	t.Helper()
+	var doc struct {
+		OpenAPI    string `json:"openapi"`
+		Components struct {
+			Schemas map[string]*schema `json:"schemas"`
+		} `json:"components"`
+	}
+	if err := json.Unmarshal(OpenAPISpec, &doc); err != nil {
+		t.Fatalf("openapi.json is not valid JSON: %v", err)
+	}
+	if !strings.HasPrefix(doc.OpenAPI, "3.") {
+		t.Fatalf("openapi = %q, want 3.x", doc.OpenAPI)
+	}
+	return doc.Components.Schemas
+}
+
+// TestOpenAPISchemasMatchStructs fails when a struct in this package and its
+// schema in openapi.json disagree on fields, types or required fields
+func TestOpenAPISchemasMatchStructs(t *testing.T) {
+	schemas := loadSpec(t)
+	structs := goStructs(t)
+
+	for name := range schemas {
+		if structs[name] == nil {
+			t.Errorf("openapi.json has schema %s with no matching struct", name)
+		}
+	}
+
+	for name, st := range structs {
+		s := schemas[name]
+		if s == nil {
+			t.Errorf("struct %s has no schema in openapi.json", name)
+			continue
+		}
+		if s.Type != "object" {
+			t.Errorf("schema %s type = %q, want object", name, s.Type)
+		}
+
+		var fields, required []string
+		for _, field := range st.Fields.List {
+			if field.Tag == nil {
+				t.Errorf("%s field %s has no json tag", name, field.Names[0].Name)
+				continue
+			}
+			tag, _ := strconv.Unquote(field.Tag.Value)
+			jsonName, options, _ := strings.Cut(reflect.StructTag(tag).Get("json"), ",")
+			fields = append(fields, jsonName)
+			if !strings.Contains(options, "omitempty") {
+				required = append(required, jsonName)
+			}
+
+			want := describeGoType(t, field.Type)
+			if got := s.Properties[jsonName].describe(); got != want {
+				t.Errorf("schema %s property %s = %s, want %s", name, jsonName, got, want)
+			}
+		}
+
+		for property := range s.Properties {
+			if !slices.Contains(fields, property) {
+				t.Errorf("schema %s property %s has no matching field", name, property)
+			}
+		}
+
+		slices.Sort(required)
+		got := slices.Sorted(slices.Values(s.Required))
+		if !slices.Equal(got, required) {
+			t.Errorf("schema %s required = %v, want %v (fields without omitempty)", name, got, required)
+		}
+	}
+}
+
+// TestOpenAPIErrorCodes fails when the codes listed for ErrorResponse and
+// the Code constants in this package differ
+func TestOpenAPIErrorCodes(t *testing.T) {
+	codes := goErrorCodes(t)
+	if len(codes) == 0 {
+		t.Fatal("found no Code constants")
+	}
+
+	schemas := loadSpec(t)
+	for _, name := range []string{"ErrorResponse", "WSServerMessage"} {
+		got := slices.Sorted(slices.Values(schemas[name].Properties["code"].Enum))
+		if !slices.Equal(got, codes) {
+			t.Errorf("schema %s code enum = %v, want %v", name, got, codes)
+		}
+	}
+}
+
+// TestOpenAPIRefsResolve fails when the document refers to a schema,
+// parameter or response it does not define
+func TestOpenAPIRefsResolve(t *testing.T) {
+	var doc map[string]any
+	if err := json.Unmarshal(OpenAPISpec, &doc); err != nil {
+		t.Fatalf("openapi.json is not valid JSON: %v", err)
+	}
+
+	var walk func(path string, node any)
+	walk = func(path string, node any) {
+		switch n := node.(type) {
+		case map[string]any:
+			if ref, ok := n["$ref"].(string); ok && !resolves(doc, ref) {
+				t.Errorf("%s: $ref %s does not resolve", path, ref)
+			}
+			for key, child := range n {
+				walk(path+"/"+key, child)
+			}
+		case []any:
+			for i, child := range n {
+				walk(path+"/"+strconv.Itoa(i), child)
+			}
+		}
+	}
+	walk("#", doc)
+}
+
+// resolves reports whether a local JSON pointer ref names a node in doc
+func resolves(doc map[string]any, ref string) bool {
+	parts, ok := strings.CutPrefix(ref, "#/")
+	if !ok {
+		return false
+	}
+	var node any = doc
+	for _, part := range strings.Split(parts, "/") {
+		m, ok := node.(map[string]any)
+		if !ok {
+			return false
+		}
+		if node, ok = m[part]; !ok {
+			return false
+		}
+	}
+	return true
+}
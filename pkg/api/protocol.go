@@ -1,15 +1,32 @@
 package api
 
+// Game modes for single-player games
+const (
+	ModeClassic  = "classic"  // Fixed random answer
+	ModeAbsurdle = "absurdle" // Adversarial: the answer dodges guesses, no round limit
+)
+
 // NewGameRequest represents a request to create a new game
+// Omitted fields fall back to the server configuration
 type NewGameRequest struct {
-	// No parameters - server uses its own configuration
+	HardMode *bool  `json:"hard_mode,omitempty"`
+	Mode     string `json:"mode,omitempty"`   // Default: "classic"
+	Boards   int    `json:"boards,omitempty"` // 2-8 for a multi-board game, default: 1
 }
 
 // NewGameResponse represents the response when creating a new game
 type NewGameResponse struct {
-	GameID    string `json:"game_id"`
-	MaxRounds int    `json:"max_rounds"`
-	Message   string `json:"message"`
+	GameID     string `json:"game_id"`
+	Mode       string `json:"mode"`
+	Boards     int    `json:"boards"`
+	MaxRounds  int    `json:"max_rounds"` // 0 means unlimited
+	WordLength int    `json:"word_length"`
+	HardMode   bool   `json:"hard_mode"`
+	Message    string `json:"message"`
+
+	// Daily puzzle only
+	PuzzleNumber int    `json:"puzzle_number,omitempty"`
+	PuzzleDate   string `json:"puzzle_date,omitempty"` // YYYY-MM-DD
 }
 
 // GuessRequest represents a guess submission
@@ -27,38 +44,182 @@ type GuessResponse struct {
 	MaxRounds    int      `json:"max_rounds"`
 	Answer       string   `json:"answer,omitempty"` // Only present when game is over
 	Message      string   `json:"message,omitempty"`
+
+	// Multi-board games only: one entry per board, Results above is empty
+	Boards []BoardResult `json:"boards,omitempty"`
+}
+
+// BoardResult represents one board's part of a guess in a multi-board game
+type BoardResult struct {
+	Results []string `json:"results,omitempty"` // Omitted for boards solved by an earlier guess
+	Solved  bool     `json:"solved"`
+	Answer  string   `json:"answer,omitempty"` // Only present when game is over
 }
 
 // GameStatusResponse represents the current game status
 type GameStatusResponse struct {
 	GameID       string          `json:"game_id"`
+	Mode         string          `json:"mode"`
+	Boards       int             `json:"boards"`
 	CurrentRound int             `json:"current_round"`
 	MaxRounds    int             `json:"max_rounds"`
+	WordLength   int             `json:"word_length"`
+	HardMode     bool            `json:"hard_mode"`
 	GameStatus   string          `json:"game_status"`
 	History      []GuessResponse `json:"history"`
 	Answer       string          `json:"answer,omitempty"` // Only present when game is over
+	PuzzleNumber int             `json:"puzzle_number,omitempty"`
+	PuzzleDate   string          `json:"puzzle_date,omitempty"`
+	Answers      []string        `json:"answers,omitempty"` // Multi-board games, only when game is over
+}
+
+// Suggestion represents a suggested next guess
+type Suggestion struct {
+	Word      string  `json:"word"`
+	Entropy   float64 `json:"entropy"`    // Expected information in bits
+	WorstCase int     `json:"worst_case"` // Candidates left in the worst case
+	Candidate bool    `json:"candidate"`  // The word could still be the answer
+}
+
+// SuggestResponse represents solver suggestions for a game in progress
+type SuggestResponse struct {
+	GameID      string       `json:"game_id"`
+	Strategy    string       `json:"strategy"`  // "entropy" or "worst_case"
+	Remaining   int          `json:"remaining"` // Possible answers left
+	Suggestions []Suggestion `json:"suggestions"`
+}
+
+// CandidatesResponse represents the answers still consistent with the guesses so far
+type CandidatesResponse struct {
+	GameID string            `json:"game_id,omitempty"`
+	Count  int               `json:"count"`
+	Words  []string          `json:"words,omitempty"`  // Only when the server lists candidates
+	Boards []BoardCandidates `json:"boards,omitempty"` // Multi-board games; Count is the total
+}
+
+// BoardCandidates represents one board's remaining candidates in a multi-board game
+type BoardCandidates struct {
+	Count int      `json:"count"`
+	Words []string `json:"words,omitempty"`
+}
+
+// JanitorStatsResponse reports what the server has reclaimed from abandoned games and rooms
+type JanitorStatsResponse struct {
+	Sweeps         int64            `json:"sweeps"`
+	LastSweep      int64            `json:"last_sweep,omitempty"` // Unix timestamp
+	GamesReclaimed int64            `json:"games_reclaimed"`
+	RoomsReclaimed int64            `json:"rooms_reclaimed"`
+	Reclaimed      map[string]int64 `json:"reclaimed"` // By reason, e.g. "idle_game", "empty_room"
+	ActiveGames    int              `json:"active_games"`
+	ActiveRooms    int              `json:"active_rooms"`
+}
+
+// HealthResponse is returned by GET /healthz and GET /readyz
+type HealthResponse struct {
+	Status string        `json:"status"` // "ok", or "unavailable" if any check failed
+	Checks []HealthCheck `json:"checks,omitempty"`
 }
 
+// HealthCheck is the result of one readiness check
+type HealthCheck struct {
+	Name   string `json:"name"` // "word_list", "storage" or "draining"
+	OK     bool   `json:"ok"`
+	Detail string `json:"detail,omitempty"`
+}
+
+// Health statuses
+const (
+	HealthOK          = "ok"
+	HealthUnavailable = "unavailable"
+)
+
 // ErrorResponse represents an error response
+// Code is stable across releases; Error is for display and may change.
 type ErrorResponse struct {
-	Error string `json:"error"`
+	Error   string            `json:"error"`
+	Code    string            `json:"code"`
+	Details map[string]string `json:"details,omitempty"` // e.g. "letter" and "position" for CodeHardMode
+}
+
+// Error codes in ErrorResponse and WebSocket error messages
+const (
+	CodeInvalidRequest      = "INVALID_REQUEST"   // Malformed body or query parameter
+	CodeInvalidWord         = "INVALID_WORD"      // Wrong length or not alphabetic
+	CodeNotInDictionary     = "NOT_IN_DICTIONARY" // Not an allowed guess
+	CodeHardMode            = "HARD_MODE"         // Ignores a revealed hint
+	CodeGameOver            = "GAME_OVER"         // The game, or the player's game in a room, is over
+	CodeNotFound            = "NOT_FOUND"
+	CodeExpired             = "EXPIRED" // Removed after inactivity
+	CodeUnauthorized        = "UNAUTHORIZED"
+	CodeForbidden           = "FORBIDDEN" // The token belongs to another player
+	CodeRoomFull            = "ROOM_FULL"
+	CodeRoomClosed          = "ROOM_CLOSED" // Already started or finished
+	CodeAlreadyInRoom       = "ALREADY_IN_ROOM"
+	CodeNotInRoom           = "NOT_IN_ROOM"
+	CodeNotHost             = "NOT_HOST"
+	CodeAlreadyStarted      = "ALREADY_STARTED"
+	CodeNotEnoughPlayers    = "NOT_ENOUGH_PLAYERS"
+	CodeRoomNotPlaying      = "ROOM_NOT_PLAYING"
+	CodeSuggestionsDisabled = "SUGGESTIONS_DISABLED"
+	CodeUnsupported         = "UNSUPPORTED" // Not available for this kind of game
+	CodeShuttingDown        = "SHUTTING_DOWN"
+	CodeInternal            = "INTERNAL"
+	CodeRateLimited         = "RATE_LIMITED"       // Too many requests; see Retry-After
+	CodeTooManyGames        = "TOO_MANY_GAMES"     // Too many unfinished games for this client
+	CodeTooManyRooms        = "TOO_MANY_ROOMS"     // The server holds as many rooms as it allows
+	CodeTooManyListeners    = "TOO_MANY_LISTENERS" // Too many clients waiting on the room
+)
+
+// MessageResponse is returned by requests that have nothing to report
+// beyond success, such as leaving or starting a room
+type MessageResponse struct {
+	Message string `json:"message"` // For display only; do not parse
 }
 
 // ============================================
 // Multi-player Room API (Task 4)
 // ============================================
 
+// Protocol versions, negotiated with the ProtocolHeader request header
+// Version 1 clients (no header) predate player tokens: they read their
+// player ID from the Message of CreateRoomResponse and JoinRoomResponse and
+// act on the room by player ID alone, so the server keeps the old message
+// format for them and issues them no token. Version 2 clients use the
+// structured fields and send the token.
+const (
+	ProtocolHeader        = "X-Wordle-Protocol"
+	ProtocolVersion       = 2 // Current version
+	LegacyProtocolVersion = 1 // Version assumed when the header is missing
+)
+
+// Room requests that act as a player (start, leave, guess, candidates)
+// authenticate with the token issued on create or join:
+//
+//	Authorization: Bearer <token>
+//
+// Protocol 1 players, who have no token, send their player ID instead.
+const (
+	AuthHeader = "Authorization"
+	AuthScheme = "Bearer"
+)
+
 // CreateRoomRequest represents a request to create a multiplayer room
 type CreateRoomRequest struct {
 	Nickname   string `json:"nickname"`
 	MaxPlayers int    `json:"max_players,omitempty"` // Default: 4
+	HardMode   *bool  `json:"hard_mode,omitempty"`   // Default: server configuration
 }
 
 // CreateRoomResponse represents the response when creating a room
 type CreateRoomResponse struct {
-	RoomID    string `json:"room_id"`
-	MaxRounds int    `json:"max_rounds"`
-	Message   string `json:"message"`
+	RoomID     string `json:"room_id"`
+	PlayerID   string `json:"player_id"`
+	Token      string `json:"token,omitempty"` // Secret player token for later requests; none for protocol 1
+	IsHost     bool   `json:"is_host"`
+	MaxRounds  int    `json:"max_rounds"`
+	WordLength int    `json:"word_length"`
+	HardMode   bool   `json:"hard_mode"`
+	Message    string `json:"message"` // For display only; do not parse
 }
 
 // JoinRoomRequest represents a request to join a room
@@ -68,16 +229,20 @@ type JoinRoomRequest struct {
 
 // JoinRoomResponse represents the response when joining a room
 type JoinRoomResponse struct {
-	RoomID    string   `json:"room_id"`
-	MaxRounds int      `json:"max_rounds"`
-	Players   []string `json:"players"` // List of player nicknames
-	IsHost    bool     `json:"is_host"`
-	Message   string   `json:"message"`
+	RoomID     string   `json:"room_id"`
+	PlayerID   string   `json:"player_id"`
+	Token      string   `json:"token,omitempty"` // Secret player token for later requests; none for protocol 1
+	IsHost     bool     `json:"is_host"`
+	MaxRounds  int      `json:"max_rounds"`
+	WordLength int      `json:"word_length"`
+	HardMode   bool     `json:"hard_mode"`
+	Players    []string `json:"players"` // List of player nicknames
+	Message    string   `json:"message"` // For display only; do not parse
 }
 
 // RoomGuessRequest represents a guess in multiplayer mode
 type RoomGuessRequest struct {
-	PlayerID string `json:"player_id"`
+	PlayerID string `json:"player_id,omitempty"` // Optional; must match the token's player. Required for protocol 1
 	Guess    string `json:"guess"`
 }
 
@@ -94,15 +259,80 @@ type PlayerProgress struct {
 }
 
 // RoomProgressResponse represents the progress of all players in a room
+// When Delta is set (in reply to ?since_version=), Players, Winner, Ranking
+// and Answer are left out and Events holds the changes since that version.
 type RoomProgressResponse struct {
 	RoomID    string           `json:"room_id"`
 	Status    string           `json:"status"` // "waiting", "playing", "finished"
-	Players   []PlayerProgress `json:"players"`
+	Players   []PlayerProgress `json:"players,omitempty"`
 	Winner    string           `json:"winner,omitempty"`  // PlayerID of winner
 	Ranking   []string         `json:"ranking,omitempty"` // Sorted PlayerIDs by rank
 	Answer    string           `json:"answer,omitempty"`  // Only when game finished
 	Version   int              `json:"version"`           // For long polling
 	Timestamp int64            `json:"timestamp"`         // Unix timestamp
+	Delta     bool             `json:"delta,omitempty"`
+	Events    []RoomEvent      `json:"events,omitempty"`  // Only when Delta is set
+	Message   string           `json:"message,omitempty"` // "server shutting down" on the last progress before a restart
+}
+
+// Room event types, streamed by GET /room/:id/events
+const (
+	EventPlayerJoined   = "player_joined"
+	EventPlayerLeft     = "player_left"
+	EventGameStarted    = "game_started"
+	EventGuessMade      = "guess_made"
+	EventPlayerFinished = "player_finished"
+	EventRoomFinished   = "room_finished"
+	EventProgress       = "progress" // Full snapshot: on connect, and when events were missed
+	EventExpired        = "expired"  // The room was removed; the stream ends
+)
+
+// RoomEvent is one change to a room
+// Every change bumps the room version; the events of one change share it.
+type RoomEvent struct {
+	Type      string         `json:"type"`
+	Version   int            `json:"version"`
+	PlayerID  string         `json:"player_id,omitempty"`
+	Nickname  string         `json:"nickname,omitempty"` // player_joined
+	Host      string         `json:"host,omitempty"`     // player_left: the host afterwards
+	Guess     *GuessResponse `json:"guess,omitempty"`    // guess_made
+	Status    string         `json:"status,omitempty"`   // player_finished: "won" or "lost"
+	Winner    string         `json:"winner,omitempty"`   // room_finished
+	Ranking   []string       `json:"ranking,omitempty"`  // room_finished
+	Answer    string         `json:"answer,omitempty"`   // room_finished
+	Timestamp int64          `json:"timestamp"`          // Unix timestamp
+}
+
+// WebSocket message types for GET /room/:id/ws
+// The server sends a WSProgress message on connect and after every change.
+// Clients send WSGuess, WSStart and WSLeave; the reply has the same type and
+// ID, or type WSError.
+const (
+	WSProgress = "progress"
+	WSGuess    = "guess"
+	WSStart    = "start"
+	WSLeave    = "leave"
+	WSError    = "error"
+	WSExpired  = "expired" // Server: the room was removed; the connection closes
+)
+
+// WSClientMessage is a message from the client over the room WebSocket
+type WSClientMessage struct {
+	Type  string `json:"type"`
+	ID    string `json:"id,omitempty"`    // Echoed in the reply
+	Guess string `json:"guess,omitempty"` // For WSGuess
+}
+
+// WSServerMessage is a message from the server over the room WebSocket
+type WSServerMessage struct {
+	Type     string                `json:"type"`
+	ID       string                `json:"id,omitempty"`       // ID of the client message answered
+	Progress *RoomProgressResponse `json:"progress,omitempty"` // For WSProgress
+	Result   *GuessResponse        `json:"result,omitempty"`   // For WSGuess
+	Message  string                `json:"message,omitempty"`
+	Error    string                `json:"error,omitempty"`   // For WSError
+	Code     string                `json:"code,omitempty"`    // For WSError, as in ErrorResponse
+	Details  map[string]string     `json:"details,omitempty"` // For WSError, as in ErrorResponse
 }
 
 // RoomStatusResponse represents the current room status
@@ -112,6 +342,8 @@ type RoomStatusResponse struct {
 	PlayerCount int      `json:"player_count"`
 	MaxPlayers  int      `json:"max_players"`
 	MaxRounds   int      `json:"max_rounds"`
+	WordLength  int      `json:"word_length"`
+	HardMode    bool     `json:"hard_mode"`
 	Players     []string `json:"players"` // List of player nicknames
 	Host        string   `json:"host"`    // Host player ID
 }
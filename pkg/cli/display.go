@@ -2,8 +2,10 @@ package cli
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/admin/wordle/internal/game"
+	"github.com/admin/wordle/internal/solver"
 )
 
 // Display handles all output formatting and display logic
@@ -21,8 +23,17 @@ func (d *Display) ShowWelcome() {
 }
 
 // ShowGameStart displays game start information
-func (d *Display) ShowGameStart(maxRounds int) {
-	fmt.Printf("\nGame started! You have %d attempts to guess the 5-letter word.\n", maxRounds)
+// maxRounds of 0 means an adversarial game with unlimited attempts
+func (d *Display) ShowGameStart(maxRounds, wordLength int, hardMode bool) {
+	if maxRounds == 0 {
+		fmt.Printf("\nAbsurdle started! Guess the %d-letter word in as many attempts as you need.\n", wordLength)
+		fmt.Println("The answer is not chosen yet - it keeps dodging your guesses.")
+	} else {
+		fmt.Printf("\nGame started! You have %d attempts to guess the %d-letter word.\n", maxRounds, wordLength)
+	}
+	if hardMode {
+		fmt.Println("Hard mode: revealed hints must be used in subsequent guesses.")
+	}
 	fmt.Println("After each guess, you'll see:")
 	fmt.Println("  'O' = correct letter in correct spot (Hit)")
 	fmt.Println("  '?' = correct letter in wrong spot (Present)")
@@ -30,8 +41,17 @@ func (d *Display) ShowGameStart(maxRounds int) {
 	fmt.Println()
 }
 
+// ShowDailyPuzzle displays the daily puzzle number and date
+func (d *Display) ShowDailyPuzzle(number int, date string) {
+	fmt.Printf("Daily puzzle #%d (%s)\n\n", number, date)
+}
+
 // ShowPrompt displays the input prompt for current round
 func (d *Display) ShowPrompt(currentRound, maxRounds int) {
+	if maxRounds == 0 {
+		fmt.Printf("Attempt %d - Enter your guess: ", currentRound+1)
+		return
+	}
 	fmt.Printf("Attempt %d/%d - Enter your guess: ", currentRound+1, maxRounds)
 }
 
@@ -76,12 +96,128 @@ func (d *Display) ShowFinalResults(history []game.GuessResult) {
 	}
 }
 
+// ShowDailyResult displays a comparable daily score, e.g. "Daily #12 3/6"
+func (d *Display) ShowDailyResult(number int, status game.GameStatus, currentRound, maxRounds int) {
+	if status == game.Won {
+		fmt.Printf("\nDaily #%d %d/%d\n", number, currentRound, maxRounds)
+	} else {
+		fmt.Printf("\nDaily #%d X/%d\n", number, maxRounds)
+	}
+}
+
+// boardsPerRow is the number of boards ShowBoards renders side by side
+const boardsPerRow = 4
+
+// ShowMultiGameStart displays multi-board game start information
+func (d *Display) ShowMultiGameStart(numBoards, maxRounds, wordLength int, hardMode bool) {
+	fmt.Printf("\nGame started! You have %d attempts to guess %d %d-letter words.\n", maxRounds, numBoards, wordLength)
+	fmt.Println("Every guess is played on all unsolved boards.")
+	if hardMode {
+		fmt.Println("Hard mode: revealed hints must be used in subsequent guesses.")
+	}
+	fmt.Println()
+}
+
+// ShowBoards displays the guesses on every board side by side
+// Boards wrap to a new row after boardsPerRow; solved boards stop growing
+func (d *Display) ShowBoards(boards []*game.Game) {
+	for start := 0; start < len(boards); start += boardsPerRow {
+		row := boards[start:min(start+boardsPerRow, len(boards))]
+
+		// Each cell is "GUESS PATTERN"; headers may be wider than short words
+		width := 2*row[0].WordLength + 1
+		headers := make([]string, len(row))
+		rounds := 0
+		for i, board := range row {
+			headers[i] = fmt.Sprintf("Board %d", start+i+1)
+			if board.GetStatus() == game.Won {
+				headers[i] += " *"
+			}
+			width = max(width, len(headers[i]))
+			rounds = max(rounds, len(board.History))
+		}
+
+		fmt.Println(joinCells(headers, width))
+		for round := 0; round < rounds; round++ {
+			cells := make([]string, len(row))
+			for i, board := range row {
+				if round < len(board.History) {
+					h := board.History[round]
+					cells[i] = h.Guess + " " + game.FormatResult(h)
+				}
+			}
+			fmt.Println(joinCells(cells, width))
+		}
+		fmt.Println()
+	}
+}
+
+// joinCells pads each cell to width and joins them with a gap
+func joinCells(cells []string, width int) string {
+	padded := make([]string, len(cells))
+	for i, cell := range cells {
+		padded[i] = fmt.Sprintf("%-*s", width, cell)
+	}
+	return strings.TrimRight(strings.Join(padded, "   "), " ")
+}
+
+// ShowMultiGameOver displays the multi-board game over message
+func (d *Display) ShowMultiGameOver(status game.GameStatus, solved, numBoards, currentRound int, answers []string) {
+	fmt.Println("==================")
+	if status == game.Won {
+		fmt.Printf("🎉 Congratulations! You solved all %d boards in %d attempt(s)!\n", numBoards, currentRound)
+	} else {
+		fmt.Printf("😔 Game Over! You solved %d of %d boards.\n", solved, numBoards)
+	}
+	fmt.Printf("The answers were: %s\n", strings.Join(answers, ", "))
+}
+
+// ShowSuggestions displays solver suggestions for the next guess
+func (d *Display) ShowSuggestions(suggestions []solver.Suggestion, remaining int) {
+	fmt.Printf("%d possible answer(s) left. Suggested guesses:\n", remaining)
+	for i, s := range suggestions {
+		marker := ""
+		if s.Candidate {
+			marker = "  (possible answer)"
+		}
+		fmt.Printf("  %d. %s  %.2f bits, worst case %d left%s\n", i+1, s.Word, s.Entropy, s.WorstCase, marker)
+	}
+	fmt.Println()
+}
+
+// ShowCandidates displays how many answers are still possible, listing them if requested
+// label prefixes the line, e.g. "Board 2"; empty for single-board games
+func (d *Display) ShowCandidates(label string, words []string, list bool) {
+	if label != "" {
+		fmt.Printf("%s: ", label)
+	}
+	fmt.Printf("%d possible answer(s) left", len(words))
+	if list && len(words) > 0 {
+		fmt.Printf(": %s", strings.Join(words, ", "))
+	}
+	fmt.Println()
+}
+
 // ShowConfigError displays configuration error message
 func (d *Display) ShowConfigError(err error) {
 	fmt.Printf("Error loading configuration: %v\n", err)
 	fmt.Println("Using default configuration...")
 }
 
+// ShowResumed displays the guesses of a resumed game
+func (d *Display) ShowResumed(history []game.GuessResult) {
+	fmt.Println("Resuming your saved game.")
+	for i, h := range history {
+		fmt.Printf("  %d. %s  %s\n", i+1, h.Guess, game.FormatResult(h))
+	}
+	fmt.Println()
+}
+
+// ShowGameSaved displays where the game was saved
+func (d *Display) ShowGameSaved(path string) {
+	fmt.Printf("Game saved to %s. Run with -resume to continue.\n", path)
+}
+
 // ShowQuitMessage displays quit message
 func (d *Display) ShowQuitMessage() {
 	fmt.Println("Thanks for playing!")
@@ -26,6 +26,16 @@ func (r *InputReader) ReadGuess() (string, bool) {
 	return strings.TrimSpace(r.scanner.Text()), true
 }
 
+// IsHintCommand checks if the input asks for solver suggestions
+func IsHintCommand(input string) bool {
+	return strings.ToLower(input) == "hint"
+}
+
+// IsLeftCommand checks if the input asks for the remaining candidates
+func IsLeftCommand(input string) bool {
+	return strings.ToLower(input) == "/left"
+}
+
 // IsQuitCommand checks if the input is a quit command
 func IsQuitCommand(input string) bool {
 	lower := strings.ToLower(input)
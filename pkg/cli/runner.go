@@ -1,20 +1,31 @@
 package cli
 
 import (
+	"errors"
 	"fmt"
 	"io"
 	"os"
+	"time"
 
 	"github.com/admin/wordle/internal/config"
 	"github.com/admin/wordle/internal/game"
+	"github.com/admin/wordle/internal/solver"
 )
 
+// hintCount is the number of suggestions shown by the hint command
+const hintCount = 5
+
 // Runner manages the game execution flow
 type Runner struct {
 	display    *Display
 	input      *InputReader
 	configPath string
 	wordsPath  string
+	hardMode   bool
+	daily      bool
+	absurdle   bool
+	boards     int
+	resume     bool
 }
 
 // NewRunner creates a new game runner
@@ -27,6 +38,32 @@ func NewRunner(reader io.Reader, configPath string, wordsPath string) *Runner {
 	}
 }
 
+// SetHardMode forces hard mode on regardless of the configuration
+func (r *Runner) SetHardMode(enabled bool) {
+	r.hardMode = enabled
+}
+
+// SetDaily plays today's daily puzzle instead of a random word
+func (r *Runner) SetDaily(enabled bool) {
+	r.daily = enabled
+}
+
+// SetAbsurdle plays the adversarial mode where the answer dodges every guess
+func (r *Runner) SetAbsurdle(enabled bool) {
+	r.absurdle = enabled
+}
+
+// SetBoards plays n boards at once (2 = Dordle, 4 = Quordle, 8 = Octordle)
+// Values below 2 play a single board
+func (r *Runner) SetBoards(n int) {
+	r.boards = n
+}
+
+// SetResume continues the game saved by the last quit instead of starting a new one
+func (r *Runner) SetResume(enabled bool) {
+	r.resume = enabled
+}
+
 // Run starts and manages the game loop
 func (r *Runner) Run() error {
 	// Show welcome
@@ -34,44 +71,145 @@ func (r *Runner) Run() error {
 
 	// Load configuration
 	cfg, err := r.loadConfiguration()
-	if err != nil {
+	switch {
+	case errors.Is(err, config.ErrNoConfigFile):
 		r.display.ShowConfigError(err)
 		cfg = config.DefaultConfig()
+	case err != nil:
+		return fmt.Errorf("error loading configuration: %w", err)
+	}
+
+	if r.boards > 1 {
+		return r.runMulti(cfg)
 	}
 
-	// Create game
-	g, err := game.NewGame(cfg.MaxRounds, cfg.WordList)
+	statePath, err := DefaultStatePath()
 	if err != nil {
-		return fmt.Errorf("error creating game: %w", err)
+		return fmt.Errorf("error locating saved game: %w", err)
+	}
+
+	// Create game, or pick up the saved one
+	var g *game.Game
+	if r.resume {
+		g, err = r.resumeGame(statePath, cfg)
+		if err != nil {
+			return fmt.Errorf("error resuming game: %w", err)
+		}
+	} else {
+		g, err = r.newGame(cfg)
+		if err != nil {
+			return fmt.Errorf("error creating game: %w", err)
+		}
+		g.HardMode = cfg.HardMode || r.hardMode
+		g.Dictionary = cfg.Dictionary()
+		g.Normalization = cfg.Normalization()
 	}
 
 	// Show game start info
-	r.display.ShowGameStart(cfg.MaxRounds)
+	r.display.ShowGameStart(g.MaxRounds, g.WordLength, g.HardMode)
+	if g.PuzzleNumber > 0 {
+		r.display.ShowDailyPuzzle(g.PuzzleNumber, g.PuzzleDate)
+	}
+	if r.resume {
+		r.display.ShowResumed(g.History)
+	}
+
+	// Run game loop; quitting saves the game for -resume, while the end of
+	// input (e.g. a closed pipe) leaves any earlier save alone
+	switch r.runGameLoop(g, cfg.ListCandidates) {
+	case playerQuit:
+		if err := saveGame(statePath, g); err != nil {
+			return fmt.Errorf("error saving game: %w", err)
+		}
+		r.display.ShowGameSaved(statePath)
+		r.display.ShowQuitMessage()
+		return nil
+	case inputEnded:
+		return nil
+	}
 
-	// Run game loop
-	r.runGameLoop(g)
+	// A finished game must not be resumed again
+	if r.resume {
+		os.Remove(statePath)
+	}
 
 	// Show game over
 	r.display.ShowGameOver(g.GetStatus(), g.CurrentRound, g.MaxRounds, g.Answer)
 	r.display.ShowFinalResults(g.History)
+	if g.PuzzleNumber > 0 {
+		r.display.ShowDailyResult(g.PuzzleNumber, g.GetStatus(), g.CurrentRound, g.MaxRounds)
+	}
 
 	return nil
 }
 
+// newGame creates a random, adversarial or daily game from the configuration
+func (r *Runner) newGame(cfg *config.Config) (*game.Game, error) {
+	if r.absurdle {
+		return game.NewAdversarialGame(cfg.WordLength, cfg.WordList)
+	}
+	if !r.daily {
+		return game.NewGame(cfg.MaxRounds, cfg.WordLength, cfg.WordList)
+	}
+
+	epoch, err := cfg.DailyEpochTime()
+	if err != nil {
+		return nil, err
+	}
+	return game.NewDailyGame(cfg.MaxRounds, cfg.WordLength, cfg.WordList, time.Now(), epoch, cfg.DailySeed)
+}
+
+// resumeGame loads the saved game and restores what the save leaves to the configuration
+func (r *Runner) resumeGame(statePath string, cfg *config.Config) (*game.Game, error) {
+	g, err := loadGame(statePath)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, errors.New("no saved game")
+	}
+	if err != nil {
+		return nil, err
+	}
+	if g.IsGameOver() {
+		return nil, errors.New("saved game is already over")
+	}
+
+	g.WordList = game.FilterWords(cfg.WordList, g.WordLength)
+	g.Dictionary = cfg.Dictionary()
+	return g, nil
+}
+
+// loopEnd is how runGameLoop ended
+type loopEnd int
+
+const (
+	gameEnded  loopEnd = iota // The game was won or lost
+	playerQuit                // The player entered the quit command
+	inputEnded                // Input ended before the game did
+)
+
 // runGameLoop executes the main game loop
-func (r *Runner) runGameLoop(g *game.Game) {
+// listCandidates makes the /left command list the remaining words, not just count them
+func (r *Runner) runGameLoop(g *game.Game, listCandidates bool) loopEnd {
 	for !g.IsGameOver() {
 		r.display.ShowPrompt(g.CurrentRound, g.MaxRounds)
 
 		guess, ok := r.input.ReadGuess()
 		if !ok {
-			break
+			return inputEnded
 		}
 
 		// Check for quit command
 		if IsQuitCommand(guess) {
-			r.display.ShowQuitMessage()
-			os.Exit(0)
+			return playerQuit
+		}
+
+		if IsLeftCommand(guess) {
+			r.display.ShowCandidates("", g.RemainingCandidates(), listCandidates)
+			continue
+		}
+
+		if IsHintCommand(guess) {
+			r.display.ShowSuggestions(solver.Suggest(g, solver.Entropy, hintCount), len(g.RemainingCandidates()))
+			continue
 		}
 
 		// Process guess
@@ -85,15 +223,66 @@ func (r *Runner) runGameLoop(g *game.Game) {
 		r.display.ShowGuessResult(result)
 		r.display.ShowHistory(g.History)
 	}
+	return gameEnded
 }
 
-// loadConfiguration tries to load config from file, returns error if not found
-func (r *Runner) loadConfiguration() (*config.Config, error) {
-	// Try to load from specified config path
-	if _, err := os.Stat(r.configPath); err != nil {
-		return nil, fmt.Errorf("configuration file not found: %s", r.configPath)
+// runMulti plays a multi-board game from the configuration
+func (r *Runner) runMulti(cfg *config.Config) error {
+	m, err := game.NewMultiGame(r.boards, cfg.WordLength, cfg.WordList)
+	if err != nil {
+		return fmt.Errorf("error creating game: %w", err)
+	}
+	for _, g := range m.Boards {
+		g.HardMode = cfg.HardMode || r.hardMode
+		g.Dictionary = cfg.Dictionary()
+		g.Normalization = cfg.Normalization()
 	}
 
+	r.display.ShowMultiGameStart(len(m.Boards), m.MaxRounds, m.WordLength, m.Boards[0].HardMode)
+
+	for !m.IsGameOver() {
+		r.display.ShowPrompt(m.CurrentRound, m.MaxRounds)
+
+		guess, ok := r.input.ReadGuess()
+		if !ok {
+			break
+		}
+
+		// Check for quit command (multi-board games are not saved)
+		if IsQuitCommand(guess) {
+			r.display.ShowQuitMessage()
+			return nil
+		}
+
+		if IsLeftCommand(guess) {
+			for i, board := range m.Boards {
+				if !board.IsGameOver() {
+					r.display.ShowCandidates(fmt.Sprintf("Board %d", i+1), board.RemainingCandidates(), cfg.ListCandidates)
+				}
+			}
+			continue
+		}
+
+		if IsHintCommand(guess) {
+			r.display.ShowError(errors.New("hints are not available for multi-board games"))
+			continue
+		}
+
+		if _, err := m.MakeGuess(guess); err != nil {
+			r.display.ShowError(err)
+			continue
+		}
+
+		r.display.ShowBoards(m.Boards)
+	}
+
+	r.display.ShowMultiGameOver(m.GetStatus(), m.SolvedCount(), len(m.Boards), m.CurrentRound, m.Answers())
+	return nil
+}
+
+// loadConfiguration loads config from file, returning config.ErrNoConfigFile
+// if there is none
+func (r *Runner) loadConfiguration() (*config.Config, error) {
 	cfg, err := config.LoadConfig(r.configPath)
 	if err != nil {
 		return nil, err
@@ -105,7 +294,7 @@ func (r *Runner) loadConfiguration() (*config.Config, error) {
 		if err != nil {
 			return nil, fmt.Errorf("failed to load words file: %w", err)
 		}
-		cfg.WordList = words
+		cfg.WordList = cfg.NormalizeWords(words)
 	}
 
 	return cfg, nil
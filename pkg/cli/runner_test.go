@@ -0,0 +1,80 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// tempStatePath keeps saved games under a temporary config directory for
+// the rest of the test and returns the saved game file
+func tempStatePath(t *testing.T) string {
+	t.Helper()
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	statePath, err := DefaultStatePath()
+	if err != nil {
+		t.Fatalf("DefaultStatePath() error = %v", err)
+	}
+	return statePath
+}
+
+// newTestRunner returns a runner reading input, with CRANE as the only
+// answer so that guessing ABOUT never ends the game
+func newTestRunner(t *testing.T, input string) *Runner {
+	t.Helper()
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(configPath, []byte("max_rounds: 6\nword_length: 5\nword_list: [CRANE]\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return NewRunner(strings.NewReader(input), configPath, "")
+}
+
+func TestRunSavesOnlyOnQuit(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     string
+		wantSaved bool
+	}{
+		{"quit", "quit\n", true},
+		{"quit after a guess", "ABOUT\nexit\n", true},
+		{"end of input", "", false},
+		{"end of input after a guess", "ABOUT\n", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			statePath := tempStatePath(t)
+			if err := newTestRunner(t, tt.input).Run(); err != nil {
+				t.Fatalf("Run() error = %v", err)
+			}
+
+			_, err := os.Stat(statePath)
+			if saved := err == nil; saved != tt.wantSaved {
+				t.Errorf("saved = %v, want %v", saved, tt.wantSaved)
+			}
+		})
+	}
+}
+
+func TestRunResumeKeepsSaveAtEndOfInput(t *testing.T) {
+	statePath := tempStatePath(t)
+	if err := newTestRunner(t, "ABOUT\nquit\n").Run(); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	saved, err := os.ReadFile(statePath)
+	if err != nil {
+		t.Fatalf("no saved game after quit: %v", err)
+	}
+
+	// A closed stdin must neither overwrite nor delete the save
+	runner := newTestRunner(t, "")
+	runner.SetResume(true)
+	if err := runner.Run(); err != nil {
+		t.Fatalf("Run() with resume error = %v", err)
+	}
+	after, err := os.ReadFile(statePath)
+	if err != nil || string(after) != string(saved) {
+		t.Errorf("saved game after end of input = %q, %v; want it unchanged", after, err)
+	}
+}
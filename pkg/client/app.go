@@ -5,14 +5,22 @@ import (
 	"fmt"
 	"io"
 	"strings"
+	"unicode/utf8"
 
 	"github.com/admin/wordle/pkg/api"
 )
 
+// hintCount is the number of suggestions shown by the hint command
+const hintCount = 5
+
 // App represents the client application
 type App struct {
-	client *Client
-	reader *bufio.Scanner
+	client   *Client
+	reader   *bufio.Scanner
+	hardMode bool
+	daily    bool
+	absurdle bool
+	boards   int
 }
 
 // NewApp creates a new client application
@@ -23,13 +31,48 @@ func NewApp(serverURL string, reader io.Reader) *App {
 	}
 }
 
+// SetHardMode requests hard mode for the game instead of the server default
+func (a *App) SetHardMode(enabled bool) {
+	a.hardMode = enabled
+}
+
+// SetDaily plays today's daily puzzle instead of a random word
+func (a *App) SetDaily(enabled bool) {
+	a.daily = enabled
+}
+
+// SetAbsurdle plays the adversarial mode where the answer dodges every guess
+func (a *App) SetAbsurdle(enabled bool) {
+	a.absurdle = enabled
+}
+
+// SetBoards plays n boards at once (2 = Dordle, 4 = Quordle, 8 = Octordle)
+// Values below 2 play a single board
+func (a *App) SetBoards(n int) {
+	a.boards = n
+}
+
 // Run starts the client application
 func (a *App) Run() error {
 	a.showWelcome()
 
 	// Create new game on server
 	fmt.Println("Connecting to server and creating new game...")
-	gameResp, err := a.client.NewGame()
+	req := api.NewGameRequest{}
+	if a.hardMode {
+		req.HardMode = &a.hardMode
+	}
+	if a.absurdle {
+		req.Mode = api.ModeAbsurdle
+	}
+	if a.boards > 1 {
+		req.Boards = a.boards
+	}
+	newGame := a.client.NewGame
+	if a.daily {
+		newGame = a.client.NewDailyGame
+	}
+	gameResp, err := newGame(req)
 	if err != nil {
 		return fmt.Errorf("failed to create game: %w", err)
 	}
@@ -39,7 +82,11 @@ func (a *App) Run() error {
 	// Game loop
 	currentRound := 0
 	for {
-		fmt.Printf("Attempt %d/%d - Enter your guess: ", currentRound+1, gameResp.MaxRounds)
+		if gameResp.MaxRounds == 0 {
+			fmt.Printf("Attempt %d - Enter your guess: ", currentRound+1)
+		} else {
+			fmt.Printf("Attempt %d/%d - Enter your guess: ", currentRound+1, gameResp.MaxRounds)
+		}
 
 		if !a.reader.Scan() {
 			break
@@ -53,6 +100,16 @@ func (a *App) Run() error {
 			break
 		}
 
+		if strings.ToLower(guess) == "/left" {
+			a.showCandidates()
+			continue
+		}
+
+		if strings.ToLower(guess) == "hint" {
+			a.showHint()
+			continue
+		}
+
 		// Send guess to server
 		response, err := a.client.MakeGuess(guess)
 		if err != nil {
@@ -66,7 +123,7 @@ func (a *App) Run() error {
 
 		// Check if game is over
 		if response.GameOver {
-			a.showGameOver(response)
+			a.showGameOver(response, gameResp.PuzzleNumber)
 			break
 		}
 	}
@@ -84,7 +141,21 @@ func (a *App) showWelcome() {
 func (a *App) showGameInfo(gameResp *api.NewGameResponse) {
 	fmt.Printf("\n%s\n", gameResp.Message)
 	fmt.Printf("Game ID: %s\n", gameResp.GameID)
-	fmt.Printf("You have %d attempts to guess the 5-letter word.\n", gameResp.MaxRounds)
+	if gameResp.PuzzleNumber > 0 {
+		fmt.Printf("Daily puzzle #%d (%s)\n", gameResp.PuzzleNumber, gameResp.PuzzleDate)
+	}
+	if gameResp.Boards > 1 {
+		fmt.Printf("You have %d attempts to guess %d %d-letter words.\n", gameResp.MaxRounds, gameResp.Boards, wordLengthOrDefault(gameResp.WordLength))
+		fmt.Println("Every guess is played on all unsolved boards.")
+	} else if gameResp.Mode == api.ModeAbsurdle {
+		fmt.Printf("Absurdle: guess the %d-letter word in as many attempts as you need.\n", wordLengthOrDefault(gameResp.WordLength))
+		fmt.Println("The answer is not chosen yet - it keeps dodging your guesses.")
+	} else {
+		fmt.Printf("You have %d attempts to guess the %d-letter word.\n", gameResp.MaxRounds, wordLengthOrDefault(gameResp.WordLength))
+	}
+	if gameResp.HardMode {
+		fmt.Println("Hard mode: revealed hints must be used in subsequent guesses.")
+	}
 	fmt.Println("\nAfter each guess, you'll see:")
 	fmt.Println("  'O' = correct letter in correct spot (Hit)")
 	fmt.Println("  '?' = correct letter in wrong spot (Present)")
@@ -92,11 +163,32 @@ func (a *App) showGameInfo(gameResp *api.NewGameResponse) {
 	fmt.Println()
 }
 
+// wordLengthOrDefault returns the word length reported by the server,
+// falling back to 5 for servers that predate configurable lengths
+func wordLengthOrDefault(wordLength int) int {
+	if wordLength == 0 {
+		return 5
+	}
+	return wordLength
+}
+
+// dailyScore formats a comparable daily result, e.g. "3/6" or "X/6"
+func dailyScore(won bool, rounds, maxRounds int) string {
+	if won {
+		return fmt.Sprintf("%d/%d", rounds, maxRounds)
+	}
+	return fmt.Sprintf("X/%d", maxRounds)
+}
+
 // displayResult displays the result of a guess
 func (a *App) displayResult(response *api.GuessResponse) {
-	// Results is already an array of display characters
-	result := strings.Join(response.Results, "")
-	fmt.Printf("Result: %s  (%s)\n", result, response.Guess)
+	if len(response.Boards) > 0 {
+		displayBoards(response)
+	} else {
+		// Results is already an array of display characters
+		result := strings.Join(response.Results, "")
+		fmt.Printf("Result: %s  (%s)\n", result, response.Guess)
+	}
 
 	if response.Message != "" {
 		fmt.Println(response.Message)
@@ -104,8 +196,68 @@ func (a *App) displayResult(response *api.GuessResponse) {
 	fmt.Println()
 }
 
+// displayBoards displays the result of a guess on every board of a multi-board game
+func displayBoards(response *api.GuessResponse) {
+	fmt.Printf("Guess: %s\n", response.Guess)
+	for i, board := range response.Boards {
+		switch {
+		case len(board.Results) > 0 && board.Solved:
+			fmt.Printf("  Board %d: %s  solved!\n", i+1, strings.Join(board.Results, ""))
+		case len(board.Results) > 0:
+			fmt.Printf("  Board %d: %s\n", i+1, strings.Join(board.Results, ""))
+		default:
+			fmt.Printf("  Board %d: solved\n", i+1)
+		}
+	}
+}
+
+// showCandidates displays how many answers are still possible
+func (a *App) showCandidates() {
+	resp, err := a.client.GetCandidates()
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return
+	}
+
+	if len(resp.Boards) == 0 {
+		fmt.Println(candidatesSummary(resp.Count, resp.Words))
+	}
+	for i, board := range resp.Boards {
+		fmt.Printf("Board %d: %s\n", i+1, candidatesSummary(board.Count, board.Words))
+	}
+	fmt.Println()
+}
+
+// candidatesSummary formats a remaining-candidates count, with the words if known
+func candidatesSummary(count int, words []string) string {
+	summary := fmt.Sprintf("%d possible answer(s) left", count)
+	if len(words) > 0 {
+		summary += ": " + strings.Join(words, ", ")
+	}
+	return summary
+}
+
+// showHint displays the server's suggestions for the next guess
+func (a *App) showHint() {
+	resp, err := a.client.Suggest("", hintCount)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return
+	}
+
+	fmt.Printf("%d possible answer(s) left. Suggested guesses:\n", resp.Remaining)
+	for i, s := range resp.Suggestions {
+		marker := ""
+		if s.Candidate {
+			marker = "  (possible answer)"
+		}
+		fmt.Printf("  %d. %s  %.2f bits, worst case %d left%s\n", i+1, s.Word, s.Entropy, s.WorstCase, marker)
+	}
+	fmt.Println()
+}
+
 // showGameOver displays game over information
-func (a *App) showGameOver(response *api.GuessResponse) {
+func (a *App) showGameOver(response *api.GuessResponse, puzzleNumber int) {
 	fmt.Println("\n==================")
 	if response.GameStatus == "won" {
 		fmt.Printf("🎉 %s\n", response.Message)
@@ -116,14 +268,37 @@ func (a *App) showGameOver(response *api.GuessResponse) {
 	if response.Answer != "" {
 		fmt.Printf("The answer was: %s\n", response.Answer)
 	}
+	for i, board := range response.Boards {
+		fmt.Printf("Board %d answer: %s\n", i+1, board.Answer)
+	}
+	if puzzleNumber > 0 {
+		fmt.Printf("Daily #%d %s\n", puzzleNumber, dailyScore(response.GameStatus == "won", response.CurrentRound, response.MaxRounds))
+	}
 
 	// Display final history
 	status, err := a.client.GetStatus()
 	if err == nil {
 		fmt.Println("\nFinal results:")
 		for i, h := range status.History {
+			if len(h.Boards) > 0 {
+				fmt.Printf("  %d. %s  %s\n", i+1, h.Guess, boardPatterns(h.Boards, utf8.RuneCountInString(h.Guess)))
+				continue
+			}
 			result := strings.Join(h.Results, "")
 			fmt.Printf("  %d. %s  %s\n", i+1, h.Guess, result)
 		}
 	}
 }
+
+// boardPatterns joins the per-board patterns of a guess, leaving solved boards blank
+func boardPatterns(boards []api.BoardResult, wordLength int) string {
+	patterns := make([]string, len(boards))
+	for i, board := range boards {
+		if len(board.Results) > 0 {
+			patterns[i] = strings.Join(board.Results, "")
+		} else {
+			patterns[i] = strings.Repeat(" ", wordLength)
+		}
+	}
+	return strings.Join(patterns, " ")
+}
@@ -4,8 +4,9 @@ import (
 	"bytes"
 	"encoding/json"
 	"fmt"
-	"io"
 	"net/http"
+	"net/url"
+	"strconv"
 
 	"github.com/admin/wordle/pkg/api"
 )
@@ -26,17 +27,25 @@ func NewClient(serverURL string) *Client {
 }
 
 // NewGame creates a new game on the server
-func (c *Client) NewGame() (*api.NewGameResponse, error) {
-	// Server uses its own configuration
-	req := api.NewGameRequest{}
+// Fields left unset in req use the server configuration
+func (c *Client) NewGame(req api.NewGameRequest) (*api.NewGameResponse, error) {
+	return c.createGame("/game/new", req)
+}
+
+// NewDailyGame starts today's daily puzzle on the server
+func (c *Client) NewDailyGame(req api.NewGameRequest) (*api.NewGameResponse, error) {
+	return c.createGame("/daily/new", req)
+}
 
+// createGame posts a new game request to the given path
+func (c *Client) createGame(path string, req api.NewGameRequest) (*api.NewGameResponse, error) {
 	body, err := json.Marshal(req)
 	if err != nil {
 		return nil, err
 	}
 
 	resp, err := c.client.Post(
-		c.serverURL+"/game/new",
+		c.serverURL+path,
 		"application/json",
 		bytes.NewBuffer(body),
 	)
@@ -46,7 +55,7 @@ func (c *Client) NewGame() (*api.NewGameResponse, error) {
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusCreated {
-		return nil, c.parseError(resp)
+		return nil, parseError(resp)
 	}
 
 	var response api.NewGameResponse
@@ -81,7 +90,7 @@ func (c *Client) MakeGuess(guess string) (*api.GuessResponse, error) {
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, c.parseError(resp)
+		return nil, parseError(resp)
 	}
 
 	var response api.GuessResponse
@@ -106,7 +115,7 @@ func (c *Client) GetStatus() (*api.GameStatusResponse, error) {
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, c.parseError(resp)
+		return nil, parseError(resp)
 	}
 
 	var response api.GameStatusResponse
@@ -117,14 +126,64 @@ func (c *Client) GetStatus() (*api.GameStatusResponse, error) {
 	return &response, nil
 }
 
-// parseError parses error response from server
-func (c *Client) parseError(resp *http.Response) error {
-	body, _ := io.ReadAll(resp.Body)
-	var errResp api.ErrorResponse
-	if err := json.Unmarshal(body, &errResp); err == nil {
-		return fmt.Errorf("server error: %s", errResp.Error)
+// GetCandidates retrieves the number (and, if the server lists them, the
+// words) of answers still consistent with the guesses so far
+func (c *Client) GetCandidates() (*api.CandidatesResponse, error) {
+	if c.gameID == "" {
+		return nil, fmt.Errorf("no active game, call NewGame first")
+	}
+
+	url := fmt.Sprintf("%s/game/%s/candidates", c.serverURL, c.gameID)
+	resp, err := c.client.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, parseError(resp)
+	}
+
+	var response api.CandidatesResponse
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return nil, err
+	}
+
+	return &response, nil
+}
+
+// Suggest retrieves solver suggestions for the next guess
+// An empty strategy uses the server default (entropy)
+func (c *Client) Suggest(strategy string, limit int) (*api.SuggestResponse, error) {
+	if c.gameID == "" {
+		return nil, fmt.Errorf("no active game, call NewGame first")
+	}
+
+	query := url.Values{}
+	if strategy != "" {
+		query.Set("strategy", strategy)
+	}
+	if limit > 0 {
+		query.Set("limit", strconv.Itoa(limit))
+	}
+
+	endpoint := fmt.Sprintf("%s/game/%s/suggest?%s", c.serverURL, c.gameID, query.Encode())
+	resp, err := c.client.Get(endpoint)
+	if err != nil {
+		return nil, err
 	}
-	return fmt.Errorf("server returned status %d", resp.StatusCode)
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, parseError(resp)
+	}
+
+	var response api.SuggestResponse
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return nil, err
+	}
+
+	return &response, nil
 }
 
 // GetGameID returns the current game ID
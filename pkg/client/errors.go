@@ -0,0 +1,132 @@
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/admin/wordle/pkg/api"
+)
+
+// ErrExpired is returned when the server has removed an abandoned game or room
+var ErrExpired = errors.New("expired after inactivity")
+
+// Errors reported by the server, one per api error code
+// Check for them with errors.Is; use errors.As with *APIError for the
+// server's message and details.
+var (
+	ErrInvalidRequest      = errors.New("invalid request")
+	ErrInvalidWord         = errors.New("invalid word")
+	ErrNotInDictionary     = errors.New("not in dictionary")
+	ErrHardMode            = errors.New("guess ignores a revealed hint")
+	ErrGameOver            = errors.New("game is over")
+	ErrNotFound            = errors.New("not found")
+	ErrUnauthorized        = errors.New("missing or invalid player token")
+	ErrForbidden           = errors.New("player token does not match player")
+	ErrRoomFull            = errors.New("room is full")
+	ErrRoomClosed          = errors.New("room is not accepting new players")
+	ErrAlreadyInRoom       = errors.New("already in room")
+	ErrNotInRoom           = errors.New("not in room")
+	ErrNotHost             = errors.New("only the host can start the game")
+	ErrAlreadyStarted      = errors.New("game already started")
+	ErrNotEnoughPlayers    = errors.New("not enough players to start")
+	ErrRoomNotPlaying      = errors.New("room game not in progress")
+	ErrSuggestionsDisabled = errors.New("suggestions are disabled")
+	ErrUnsupported         = errors.New("not supported for this game")
+	ErrShuttingDown        = errors.New("server shutting down")
+	ErrRateLimited         = errors.New("too many requests")
+	ErrTooManyGames        = errors.New("too many unfinished games")
+	ErrTooManyRooms        = errors.New("server has too many rooms")
+	ErrTooManyListeners    = errors.New("too many clients waiting on room")
+	ErrInternal            = errors.New("internal server error")
+)
+
+// codeErrors maps api error codes to the errors above
+var codeErrors = map[string]error{
+	api.CodeInvalidRequest:      ErrInvalidRequest,
+	api.CodeInvalidWord:         ErrInvalidWord,
+	api.CodeNotInDictionary:     ErrNotInDictionary,
+	api.CodeHardMode:            ErrHardMode,
+	api.CodeGameOver:            ErrGameOver,
+	api.CodeNotFound:            ErrNotFound,
+	api.CodeExpired:             ErrExpired,
+	api.CodeUnauthorized:        ErrUnauthorized,
+	api.CodeForbidden:           ErrForbidden,
+	api.CodeRoomFull:            ErrRoomFull,
+	api.CodeRoomClosed:          ErrRoomClosed,
+	api.CodeAlreadyInRoom:       ErrAlreadyInRoom,
+	api.CodeNotInRoom:           ErrNotInRoom,
+	api.CodeNotHost:             ErrNotHost,
+	api.CodeAlreadyStarted:      ErrAlreadyStarted,
+	api.CodeNotEnoughPlayers:    ErrNotEnoughPlayers,
+	api.CodeRoomNotPlaying:      ErrRoomNotPlaying,
+	api.CodeSuggestionsDisabled: ErrSuggestionsDisabled,
+	api.CodeUnsupported:         ErrUnsupported,
+	api.CodeShuttingDown:        ErrShuttingDown,
+	api.CodeRateLimited:         ErrRateLimited,
+	api.CodeTooManyGames:        ErrTooManyGames,
+	api.CodeTooManyRooms:        ErrTooManyRooms,
+	api.CodeTooManyListeners:    ErrTooManyListeners,
+	api.CodeInternal:            ErrInternal,
+}
+
+// statusCodes gives the code to assume when a server older than error codes
+// answers with one of these statuses
+var statusCodes = map[int]string{
+	http.StatusUnauthorized:        api.CodeUnauthorized,
+	http.StatusForbidden:           api.CodeForbidden,
+	http.StatusNotFound:            api.CodeNotFound,
+	http.StatusGone:                api.CodeExpired,
+	http.StatusTooManyRequests:     api.CodeRateLimited,
+	http.StatusServiceUnavailable:  api.CodeShuttingDown,
+	http.StatusInternalServerError: api.CodeInternal,
+}
+
+// APIError is an error response from the server
+type APIError struct {
+	StatusCode int
+	Code       string // One of the api.Code constants
+	Message    string // The server's message, for display; empty if it sent none
+	Details    map[string]string
+	RetryAfter time.Duration // From the Retry-After header of a 429; zero if none
+}
+
+// Error implements the error interface
+func (e *APIError) Error() string {
+	if e.Message == "" {
+		return fmt.Sprintf("server returned status %d", e.StatusCode)
+	}
+	return "server error: " + e.Message
+}
+
+// Is reports whether target is the error for e's code, so that
+// errors.Is(err, ErrRoomFull) works on an *APIError
+func (e *APIError) Is(target error) bool {
+	known, ok := codeErrors[e.Code]
+	return ok && known == target
+}
+
+// parseError reads an error response from the server
+func parseError(resp *http.Response) error {
+	apiErr := &APIError{StatusCode: resp.StatusCode}
+	if seconds, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil && seconds > 0 {
+		apiErr.RetryAfter = time.Duration(seconds) * time.Second
+	}
+
+	body, _ := io.ReadAll(resp.Body)
+	var errResp api.ErrorResponse
+	if err := json.Unmarshal(body, &errResp); err == nil {
+		apiErr.Code = errResp.Code
+		apiErr.Message = errResp.Error
+		apiErr.Details = errResp.Details
+	}
+
+	if apiErr.Code == "" {
+		apiErr.Code = statusCodes[resp.StatusCode]
+	}
+	return apiErr
+}
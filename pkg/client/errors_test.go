@@ -0,0 +1,82 @@
+package client
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestParseError(t *testing.T) {
+	tests := []struct {
+		name           string
+		status         int
+		retryAfter     string
+		body           string
+		wantIs         error // nil: matches no known error
+		wantCode       string
+		wantMessage    string
+		wantRetryAfter time.Duration
+	}{
+		{"coded error", http.StatusBadRequest, "", `{"error":"room is full","code":"ROOM_FULL"}`,
+			ErrRoomFull, "ROOM_FULL", "server error: room is full", 0},
+		{"details", http.StatusBadRequest, "", `{"error":"hard mode: letter 2 must be R","code":"HARD_MODE","details":{"letter":"R"}}`,
+			ErrHardMode, "HARD_MODE", "server error: hard mode: letter 2 must be R", 0},
+		{"rate limited", http.StatusTooManyRequests, "7", `{"error":"too many unfinished games for this client","code":"TOO_MANY_GAMES"}`,
+			ErrTooManyGames, "TOO_MANY_GAMES", "server error: too many unfinished games for this client", 7 * time.Second},
+		{"server without codes", http.StatusNotFound, "", `{"error":"room not found"}`,
+			ErrNotFound, "NOT_FOUND", "server error: room not found", 0},
+		{"no body", http.StatusGone, "", "",
+			ErrExpired, "EXPIRED", "server returned status 410", 0},
+		{"code this client does not know", http.StatusBadRequest, "", `{"error":"new rule","code":"NEW_RULE"}`,
+			nil, "NEW_RULE", "server error: new rule", 0},
+		{"not JSON", http.StatusBadGateway, "soon", "Bad Gateway",
+			nil, "", "server returned status 502", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if tt.retryAfter != "" {
+					w.Header().Set("Retry-After", tt.retryAfter)
+				}
+				w.WriteHeader(tt.status)
+				w.Write([]byte(tt.body))
+			}))
+			defer srv.Close()
+			resp, err := http.Get(srv.URL)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer resp.Body.Close()
+
+			err = parseError(resp)
+			var apiErr *APIError
+			if !errors.As(err, &apiErr) {
+				t.Fatalf("parseError() = %T, want *APIError", err)
+			}
+			if apiErr.StatusCode != tt.status || apiErr.Code != tt.wantCode || err.Error() != tt.wantMessage || apiErr.RetryAfter != tt.wantRetryAfter {
+				t.Errorf("parseError() = %+v (%q), want status %d, code %q, message %q, retry after %v",
+					apiErr, err, tt.status, tt.wantCode, tt.wantMessage, tt.wantRetryAfter)
+			}
+			for _, known := range codeErrors {
+				if got := errors.Is(err, known); got != (known == tt.wantIs) {
+					t.Errorf("errors.Is(err, %v) = %v, want %v", known, got, !got)
+				}
+			}
+		})
+	}
+}
+
+func TestAPIErrorIs(t *testing.T) {
+	// Each code matches its own error and no other
+	for code, want := range codeErrors {
+		err := error(&APIError{StatusCode: http.StatusBadRequest, Code: code})
+		for _, other := range codeErrors {
+			if got := errors.Is(err, other); got != (other == want) {
+				t.Errorf("errors.Is(%s error, %v) = %v", code, other, got)
+			}
+		}
+	}
+}
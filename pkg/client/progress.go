@@ -0,0 +1,95 @@
+package client
+
+import (
+	"slices"
+
+	"github.com/admin/wordle/pkg/api"
+)
+
+// applyDelta returns progress with the events of a delta response applied
+// progress is not modified, since the caller may still be reading it.
+func applyDelta(progress, delta *api.RoomProgressResponse) *api.RoomProgressResponse {
+	next := *progress
+	next.Players = make([]api.PlayerProgress, len(progress.Players))
+	for i, player := range progress.Players {
+		player.History = slices.Clone(player.History)
+		next.Players[i] = player
+	}
+
+	for _, event := range delta.Events {
+		applyEvent(&next, event)
+	}
+
+	// Point LastGuess into the new History rather than the old one
+	for i := range next.Players {
+		player := &next.Players[i]
+		player.LastGuess = nil
+		if len(player.History) > 0 {
+			player.LastGuess = &player.History[len(player.History)-1]
+		}
+	}
+
+	next.Status = delta.Status
+	next.Version = delta.Version
+	next.Timestamp = delta.Timestamp
+	next.Message = delta.Message
+	return &next
+}
+
+// applyEvent applies one room event to progress
+func applyEvent(progress *api.RoomProgressResponse, event api.RoomEvent) {
+	index := slices.IndexFunc(progress.Players, func(p api.PlayerProgress) bool {
+		return p.PlayerID == event.PlayerID
+	})
+
+	switch event.Type {
+	case api.EventPlayerJoined:
+		if index >= 0 {
+			return
+		}
+		maxRounds := 0
+		if len(progress.Players) > 0 {
+			maxRounds = progress.Players[0].MaxRounds
+		}
+		progress.Players = append(progress.Players, api.PlayerProgress{
+			PlayerID:  event.PlayerID,
+			Nickname:  event.Nickname,
+			MaxRounds: maxRounds,
+			Status:    "waiting",
+			History:   []api.GuessResponse{},
+		})
+
+	case api.EventPlayerLeft:
+		if index >= 0 {
+			progress.Players = slices.Delete(progress.Players, index, index+1)
+		}
+
+	case api.EventGameStarted:
+		progress.Status = "playing"
+		for i := range progress.Players {
+			progress.Players[i].Status = "playing"
+		}
+
+	case api.EventGuessMade:
+		if index < 0 || event.Guess == nil {
+			return
+		}
+		player := &progress.Players[index]
+		player.History = append(player.History, *event.Guess)
+		player.CurrentRound = event.Guess.CurrentRound
+		player.MaxRounds = event.Guess.MaxRounds
+
+	case api.EventPlayerFinished:
+		if index < 0 {
+			return
+		}
+		progress.Players[index].Status = event.Status
+		progress.Players[index].FinishTime = event.Timestamp
+
+	case api.EventRoomFinished:
+		progress.Status = "finished"
+		progress.Winner = event.Winner
+		progress.Ranking = event.Ranking
+		progress.Answer = event.Answer
+	}
+}
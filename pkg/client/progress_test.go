@@ -0,0 +1,108 @@
+package client
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/admin/wordle/pkg/api"
+)
+
+func TestApplyDelta(t *testing.T) {
+	slate := api.GuessResponse{Guess: "SLATE", Results: []string{"_", "_", "O", "_", "O"}, GameStatus: "in_progress", CurrentRound: 1, MaxRounds: 6}
+	crane := api.GuessResponse{Guess: "CRANE", Results: []string{"O", "O", "O", "O", "O"}, GameOver: true, GameStatus: "won", CurrentRound: 2, MaxRounds: 6}
+
+	player := func(id, status string, history ...api.GuessResponse) api.PlayerProgress {
+		p := api.PlayerProgress{PlayerID: id, Nickname: id, MaxRounds: 6, Status: status, History: history}
+		if p.History == nil {
+			p.History = []api.GuessResponse{}
+		}
+		if len(history) > 0 {
+			p.CurrentRound = history[len(history)-1].CurrentRound
+			p.LastGuess = &p.History[len(history)-1]
+		}
+		return p
+	}
+	progress := func(version int, status string, players ...api.PlayerProgress) *api.RoomProgressResponse {
+		return &api.RoomProgressResponse{RoomID: "ROOM01", Status: status, Players: players, Version: version}
+	}
+
+	tests := []struct {
+		name   string
+		before *api.RoomProgressResponse
+		status string // Of the delta
+		events []api.RoomEvent
+		want   *api.RoomProgressResponse
+	}{
+		{
+			"player joined",
+			progress(0, "waiting", player("host", "waiting")),
+			"waiting",
+			[]api.RoomEvent{{Type: api.EventPlayerJoined, Version: 1, PlayerID: "guest", Nickname: "guest"}},
+			progress(1, "waiting", player("host", "waiting"), player("guest", "waiting")),
+		},
+		{
+			"player already known",
+			progress(1, "waiting", player("host", "waiting")),
+			"waiting",
+			[]api.RoomEvent{{Type: api.EventPlayerJoined, Version: 2, PlayerID: "host", Nickname: "renamed"}},
+			progress(2, "waiting", player("host", "waiting")),
+		},
+		{
+			"player left",
+			progress(1, "waiting", player("host", "waiting"), player("guest", "waiting")),
+			"waiting",
+			[]api.RoomEvent{{Type: api.EventPlayerLeft, Version: 2, PlayerID: "guest", Host: "host"}},
+			progress(2, "waiting", player("host", "waiting")),
+		},
+		{
+			"started and guessed",
+			progress(1, "waiting", player("host", "waiting"), player("guest", "waiting")),
+			"playing",
+			[]api.RoomEvent{
+				{Type: api.EventGameStarted, Version: 2},
+				{Type: api.EventGuessMade, Version: 3, PlayerID: "host", Guess: &slate},
+			},
+			progress(3, "playing", player("host", "playing", slate), player("guest", "playing")),
+		},
+		{
+			"finished",
+			progress(3, "playing", player("host", "playing", slate), player("guest", "playing")),
+			"finished",
+			[]api.RoomEvent{
+				{Type: api.EventGuessMade, Version: 4, PlayerID: "host", Guess: &crane},
+				{Type: api.EventPlayerFinished, Version: 4, PlayerID: "host", Status: "won", Timestamp: 100},
+				{Type: api.EventRoomFinished, Version: 5, Winner: "host", Ranking: []string{"host", "guest"}, Answer: "CRANE"},
+			},
+			func() *api.RoomProgressResponse {
+				want := progress(5, "finished", player("host", "won", slate, crane), player("guest", "playing"))
+				want.Players[0].FinishTime = 100
+				want.Winner, want.Ranking, want.Answer = "host", []string{"host", "guest"}, "CRANE"
+				return want
+			}(),
+		},
+		{
+			"event for an unknown player",
+			progress(1, "playing", player("host", "playing")),
+			"playing",
+			[]api.RoomEvent{{Type: api.EventGuessMade, Version: 2, PlayerID: "ghost", Guess: &slate}},
+			progress(2, "playing", player("host", "playing")),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			before, _ := json.Marshal(tt.before)
+			delta := &api.RoomProgressResponse{RoomID: "ROOM01", Status: tt.status, Version: tt.want.Version, Delta: true, Events: tt.events}
+
+			got := applyDelta(tt.before, delta)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("applyDelta() = %+v, want %+v", got, tt.want)
+			}
+			// The caller may still be reading the progress it passed in
+			if after, _ := json.Marshal(tt.before); string(after) != string(before) {
+				t.Errorf("applyDelta() changed its input from %s to %s", before, after)
+			}
+		})
+	}
+}
@@ -3,6 +3,7 @@ package client
 import (
 	"bufio"
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"strings"
@@ -23,6 +24,8 @@ type RoomApp struct {
 	gameStarted     bool
 	gameFinished    bool
 	isHost          bool
+	wordLength      int
+	hardMode        bool
 	currentProgress *api.RoomProgressResponse
 	mu              sync.RWMutex
 	stopProgress    chan struct{}
@@ -65,6 +68,11 @@ func NewRoomApp(serverURL string, input io.Reader) *RoomApp {
 	return app
 }
 
+// SetHardMode sets the default answer for the hard mode prompt when creating a room
+func (a *RoomApp) SetHardMode(enabled bool) {
+	a.hardMode = enabled
+}
+
 // Run starts the multiplayer application
 func (a *RoomApp) Run() error {
 	fmt.Println("\n=== Multi-Player Wordle ===")
@@ -98,7 +106,7 @@ func (a *RoomApp) Run() error {
 			fmt.Println("Goodbye!")
 			return nil
 		default:
-			fmt.Println("Invalid choice. Please try again.\n")
+			fmt.Print("Invalid choice. Please try again.\n\n")
 		}
 	}
 }
@@ -118,18 +126,33 @@ func (a *RoomApp) createRoomFlow() error {
 		fmt.Sscanf(maxPlayersStr, "%d", &maxPlayers)
 	}
 
+	if a.hardMode {
+		fmt.Print("Hard mode? (Y/n): ")
+	} else {
+		fmt.Print("Hard mode? (y/N): ")
+	}
+	switch strings.ToLower(<-a.inputChan) {
+	case "y", "yes":
+		a.hardMode = true
+	case "n", "no":
+		a.hardMode = false
+	}
+
 	// Create room
 	fmt.Println("\nCreating room...")
-	resp, err := a.client.CreateRoom(nickname, maxPlayers)
+	resp, err := a.client.CreateRoom(nickname, maxPlayers, a.hardMode)
 	if err != nil {
 		return fmt.Errorf("failed to create room: %w", err)
 	}
 
 	fmt.Printf("\n✓ Room created! Room ID: %s\n", resp.RoomID)
+	fmt.Printf("Word length: %d letters, %d attempts%s\n", wordLengthOrDefault(resp.WordLength), resp.MaxRounds, hardModeLabel(resp.HardMode))
 	fmt.Printf("You are the host. Waiting for players to join...\n")
 	fmt.Printf("Share this room ID with your friends: %s\n\n", resp.RoomID)
 
 	a.isHost = true
+	a.wordLength = wordLengthOrDefault(resp.WordLength)
+	a.hardMode = resp.HardMode
 	return a.roomLobby()
 }
 
@@ -159,6 +182,8 @@ func (a *RoomApp) joinRoomFlow() error {
 		}
 
 		// Validate room ID exists
+		// Room IDs are upper case; accept them typed in any case
+		roomID = strings.ToUpper(roomID)
 		if !a.validateRoomExists(roomID) {
 			fmt.Printf("\n❌ Room '%s' does not exist!\n", roomID)
 			a.listRooms()
@@ -183,9 +208,12 @@ func (a *RoomApp) joinRoomFlow() error {
 	}
 
 	fmt.Printf("\n✓ Joined room %s!\n", resp.RoomID)
+	fmt.Printf("Word length: %d letters, %d attempts%s\n", wordLengthOrDefault(resp.WordLength), resp.MaxRounds, hardModeLabel(resp.HardMode))
 	fmt.Printf("Players in room: %s\n\n", strings.Join(resp.Players, ", "))
 
 	a.isHost = resp.IsHost
+	a.wordLength = wordLengthOrDefault(resp.WordLength)
+	a.hardMode = resp.HardMode
 	return a.roomLobby()
 }
 
@@ -379,9 +407,9 @@ func (a *RoomApp) playGame() error {
 	// Add initial log messages (only once)
 	myProgress := a.findMyProgress(progress)
 	a.screen.AddLogLine("--- Game Started ---")
-	a.screen.AddLogLine(fmt.Sprintf("Room: %s | Max Rounds: %d", a.client.GetRoomID(), myProgress.MaxRounds))
+	a.screen.AddLogLine(fmt.Sprintf("Room: %s | Max Rounds: %d | Letters: %d%s", a.client.GetRoomID(), myProgress.MaxRounds, a.wordLength, hardModeLabel(a.hardMode)))
 	a.screen.AddLogLine("O=Hit | ?=Present | _=Miss")
-	a.screen.AddLogLine("Type QUIT to exit")
+	a.screen.AddLogLine("Type /left for remaining answers, QUIT to exit")
 
 	// Start progress monitoring in background (non-blocking)
 	go a.monitorProgress()
@@ -428,6 +456,16 @@ gameLoop:
 				break gameLoop
 			}
 
+			if guess == "/LEFT" {
+				resp, err := a.client.GetCandidates()
+				if err != nil {
+					a.screen.AddLogLine(fmt.Sprintf("Error: %v", err))
+				} else {
+					a.screen.AddLogLine(candidatesSummary(resp.Count, resp.Words))
+				}
+				continue
+			}
+
 			// Submit guess
 			response, err := a.client.MakeGuess(guess)
 			if err != nil {
@@ -449,6 +487,7 @@ gameLoop:
 
 	// Stop progress monitoring
 	close(a.stopProgress)
+	a.client.Close()
 
 	// Wait a bit for final updates
 	time.Sleep(500 * time.Millisecond)
@@ -468,26 +507,38 @@ gameLoop:
 	return nil
 }
 
-// monitorProgress monitors game progress with long polling (runs in background goroutine)
+// monitorProgress monitors game progress over the WebSocket or long polling (runs in background goroutine)
 func (a *RoomApp) monitorProgress() {
 	for {
 		select {
 		case <-a.stopProgress:
 			return
 		default:
-			// Long polling - this will block until update or timeout
+			// WebSocket push or long polling - this will block until update or timeout
 			// But it's OK because it's in a separate goroutine
 			a.mu.RLock()
 			currentVersion := a.progressVersion
 			a.mu.RUnlock()
 
-			progress, err := a.client.GetProgress(currentVersion)
+			progress, err := a.client.NextProgress(currentVersion)
+			if errors.Is(err, ErrExpired) {
+				a.screen.AddLogLine("Room expired after inactivity")
+				select {
+				case a.gameFinishedChan <- struct{}{}:
+				default:
+				}
+				return
+			}
 			if err != nil {
 				// On error, wait a bit before retrying
 				time.Sleep(2 * time.Second)
 				continue
 			}
 
+			if progress.Message != "" {
+				a.screen.AddLogLine(fmt.Sprintf("Server: %s", progress.Message))
+			}
+
 			// Update received (or timeout with current state)
 			if progress.Version > currentVersion {
 				// New update available
@@ -629,6 +680,14 @@ func (a *RoomApp) showFinalResults(progress *api.RoomProgressResponse) {
 	fmt.Println()
 }
 
+// hardModeLabel returns a short suffix describing the hard mode setting
+func hardModeLabel(hardMode bool) string {
+	if hardMode {
+		return " | Hard mode"
+	}
+	return ""
+}
+
 // findMyProgress finds the current player's progress
 func (a *RoomApp) findMyProgress(progress *api.RoomProgressResponse) api.PlayerProgress {
 	for _, player := range progress.Players {
@@ -658,7 +717,7 @@ func (a *RoomApp) listRooms() {
 	}
 
 	if len(resp.Rooms) == 0 {
-		fmt.Println("\n❌ No available rooms. Create one!\n")
+		fmt.Print("\n❌ No available rooms. Create one!\n\n")
 		return
 	}
 
@@ -687,9 +746,12 @@ func (a *RoomApp) listRooms() {
 				hostName = room.Players[0]
 			}
 
-			// Format: ⏳ Room: ID  (1/2)  Host: name
+			// Format: ⏳ Room: ID  (1/2)  Host: name [hard]
 			roomInfo := fmt.Sprintf(" ⏳ Room: %-8s (%d/%d)  Host: %s",
 				room.RoomID, room.PlayerCount, room.MaxPlayers, hostName)
+			if room.HardMode {
+				roomInfo += " [hard]"
+			}
 
 			roomInfo = padRoomLine(roomInfo)
 			fmt.Printf("║%s║\n", roomInfo)
@@ -4,10 +4,13 @@ import (
 	"bytes"
 	"encoding/json"
 	"fmt"
-	"io"
 	"net/http"
+	"strconv"
+	"sync"
+	"time"
 
 	"github.com/admin/wordle/pkg/api"
+	"github.com/gorilla/websocket"
 )
 
 // RoomClient handles HTTP communication for multiplayer rooms
@@ -16,9 +19,23 @@ type RoomClient struct {
 	client    *http.Client
 	roomID    string
 	playerID  string
+	token     string // Secret player token issued on create/join
 	nickname  string
+
+	// Latest full progress, which delta responses are applied to
+	progress   *api.RoomProgressResponse
+	progressMu sync.Mutex
+
+	// Room WebSocket for progress updates; nil while long polling
+	ws        *websocket.Conn
+	wsRetryAt time.Time // Earliest time to dial the WebSocket again
+	wsMu      sync.Mutex
 }
 
+// errUnsupportedServer is returned when the server does not send the
+// structured room fields of protocol version 2
+var errUnsupportedServer = fmt.Errorf("server does not support protocol version %d; please upgrade it", api.ProtocolVersion)
+
 // NewRoomClient creates a new room client
 func NewRoomClient(serverURL string) *RoomClient {
 	return &RoomClient{
@@ -28,10 +45,11 @@ func NewRoomClient(serverURL string) *RoomClient {
 }
 
 // CreateRoom creates a new multiplayer room
-func (c *RoomClient) CreateRoom(nickname string, maxPlayers int) (*api.CreateRoomResponse, error) {
+func (c *RoomClient) CreateRoom(nickname string, maxPlayers int, hardMode bool) (*api.CreateRoomResponse, error) {
 	req := api.CreateRoomRequest{
 		Nickname:   nickname,
 		MaxPlayers: maxPlayers,
+		HardMode:   &hardMode,
 	}
 
 	body, err := json.Marshal(req)
@@ -40,16 +58,14 @@ func (c *RoomClient) CreateRoom(nickname string, maxPlayers int) (*api.CreateRoo
 	}
 
 	url := fmt.Sprintf("%s/room/create", c.serverURL)
-	resp, err := c.client.Post(url, "application/json", bytes.NewBuffer(body))
+	resp, err := c.do(http.MethodPost, url, body)
 	if err != nil {
 		return nil, err
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusCreated {
-		var errResp api.ErrorResponse
-		json.NewDecoder(resp.Body).Decode(&errResp)
-		return nil, fmt.Errorf("server error: %s", errResp.Error)
+		return nil, parseError(resp)
 	}
 
 	var response api.CreateRoomResponse
@@ -57,11 +73,14 @@ func (c *RoomClient) CreateRoom(nickname string, maxPlayers int) (*api.CreateRoo
 		return nil, err
 	}
 
-	// Extract player ID from message
+	if response.PlayerID == "" {
+		return nil, errUnsupportedServer
+	}
+
 	c.roomID = response.RoomID
+	c.playerID = response.PlayerID
+	c.token = response.Token
 	c.nickname = nickname
-	// Message format: "Room created! You are the host. Player ID: player-xxx"
-	fmt.Sscanf(response.Message, "Room created! You are the host. Player ID: %s", &c.playerID)
 
 	return &response, nil
 }
@@ -78,16 +97,14 @@ func (c *RoomClient) JoinRoom(roomID, nickname string) (*api.JoinRoomResponse, e
 	}
 
 	url := fmt.Sprintf("%s/room/%s/join", c.serverURL, roomID)
-	resp, err := c.client.Post(url, "application/json", bytes.NewBuffer(body))
+	resp, err := c.do(http.MethodPost, url, body)
 	if err != nil {
 		return nil, err
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		var errResp api.ErrorResponse
-		json.NewDecoder(resp.Body).Decode(&errResp)
-		return nil, fmt.Errorf("server error: %s", errResp.Error)
+		return nil, parseError(resp)
 	}
 
 	var response api.JoinRoomResponse
@@ -95,10 +112,14 @@ func (c *RoomClient) JoinRoom(roomID, nickname string) (*api.JoinRoomResponse, e
 		return nil, err
 	}
 
-	c.roomID = roomID
+	if response.PlayerID == "" {
+		return nil, errUnsupportedServer
+	}
+
+	c.roomID = response.RoomID
+	c.playerID = response.PlayerID
+	c.token = response.Token
 	c.nickname = nickname
-	// Extract player ID from message
-	fmt.Sscanf(response.Message, "Joined room successfully! Player ID: %s", &c.playerID)
 
 	return &response, nil
 }
@@ -106,16 +127,14 @@ func (c *RoomClient) JoinRoom(roomID, nickname string) (*api.JoinRoomResponse, e
 // StartGame starts the game (host only)
 func (c *RoomClient) StartGame() error {
 	url := fmt.Sprintf("%s/room/%s/start?player_id=%s", c.serverURL, c.roomID, c.playerID)
-	resp, err := c.client.Post(url, "application/json", nil)
+	resp, err := c.do(http.MethodPost, url, nil)
 	if err != nil {
 		return err
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		var errResp api.ErrorResponse
-		json.NewDecoder(resp.Body).Decode(&errResp)
-		return fmt.Errorf("server error: %s", errResp.Error)
+		return parseError(resp)
 	}
 
 	return nil
@@ -134,16 +153,14 @@ func (c *RoomClient) MakeGuess(guess string) (*api.GuessResponse, error) {
 	}
 
 	url := fmt.Sprintf("%s/room/%s/guess", c.serverURL, c.roomID)
-	resp, err := c.client.Post(url, "application/json", bytes.NewBuffer(body))
+	resp, err := c.do(http.MethodPost, url, body)
 	if err != nil {
 		return nil, err
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		var errResp api.ErrorResponse
-		json.NewDecoder(resp.Body).Decode(&errResp)
-		return nil, fmt.Errorf("server error: %s", errResp.Error)
+		return nil, parseError(resp)
 	}
 
 	var response api.GuessResponse
@@ -155,8 +172,15 @@ func (c *RoomClient) MakeGuess(guess string) (*api.GuessResponse, error) {
 }
 
 // GetProgress gets the current progress with long polling
+// Once it has the full progress, it asks only for the changes since then
+// and applies them.
 func (c *RoomClient) GetProgress(version int) (*api.RoomProgressResponse, error) {
+	known := c.lastProgress()
+
 	url := fmt.Sprintf("%s/room/%s/progress?version=%d", c.serverURL, c.roomID, version)
+	if known != nil {
+		url += fmt.Sprintf("&since_version=%d", known.Version)
+	}
 	resp, err := c.client.Get(url)
 	if err != nil {
 		return nil, err
@@ -164,8 +188,7 @@ func (c *RoomClient) GetProgress(version int) (*api.RoomProgressResponse, error)
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		bodyBytes, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("server error: %s", string(bodyBytes))
+		return nil, parseError(resp)
 	}
 
 	var response api.RoomProgressResponse
@@ -173,7 +196,34 @@ func (c *RoomClient) GetProgress(version int) (*api.RoomProgressResponse, error)
 		return nil, err
 	}
 
-	return &response, nil
+	progress := &response
+	if response.Delta {
+		if known == nil {
+			return nil, fmt.Errorf("server sent changes without full progress")
+		}
+		progress = applyDelta(known, &response)
+	}
+	c.setProgress(progress)
+
+	return progress, nil
+}
+
+// lastProgress returns the latest full progress of the current room, if any
+func (c *RoomClient) lastProgress() *api.RoomProgressResponse {
+	c.progressMu.Lock()
+	defer c.progressMu.Unlock()
+
+	if c.progress == nil || c.progress.RoomID != c.roomID {
+		return nil
+	}
+	return c.progress
+}
+
+// setProgress records the latest full progress
+func (c *RoomClient) setProgress(progress *api.RoomProgressResponse) {
+	c.progressMu.Lock()
+	defer c.progressMu.Unlock()
+	c.progress = progress
 }
 
 // GetRoomStatus gets the room status
@@ -186,9 +236,7 @@ func (c *RoomClient) GetRoomStatus() (*api.RoomStatusResponse, error) {
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		var errResp api.ErrorResponse
-		json.NewDecoder(resp.Body).Decode(&errResp)
-		return nil, fmt.Errorf("server error: %s", errResp.Error)
+		return nil, parseError(resp)
 	}
 
 	var response api.RoomStatusResponse
@@ -199,6 +247,28 @@ func (c *RoomClient) GetRoomStatus() (*api.RoomStatusResponse, error) {
 	return &response, nil
 }
 
+// GetCandidates gets the number (and, if the server lists them, the words)
+// of answers still consistent with the player's guesses
+func (c *RoomClient) GetCandidates() (*api.CandidatesResponse, error) {
+	url := fmt.Sprintf("%s/room/%s/candidates?player_id=%s", c.serverURL, c.roomID, c.playerID)
+	resp, err := c.do(http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, parseError(resp)
+	}
+
+	var response api.CandidatesResponse
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return nil, err
+	}
+
+	return &response, nil
+}
+
 // ListRooms lists all available rooms
 func (c *RoomClient) ListRooms() (*api.ListRoomsResponse, error) {
 	url := fmt.Sprintf("%s/room/list", c.serverURL)
@@ -209,9 +279,7 @@ func (c *RoomClient) ListRooms() (*api.ListRoomsResponse, error) {
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		var errResp api.ErrorResponse
-		json.NewDecoder(resp.Body).Decode(&errResp)
-		return nil, fmt.Errorf("server error: %s", errResp.Error)
+		return nil, parseError(resp)
 	}
 
 	var response api.ListRoomsResponse
@@ -222,6 +290,23 @@ func (c *RoomClient) ListRooms() (*api.ListRoomsResponse, error) {
 	return &response, nil
 }
 
+// do sends a request with the protocol version and, once the client has
+// one, the player token
+func (c *RoomClient) do(method, url string, body []byte) (*http.Response, error) {
+	req, err := http.NewRequest(method, url, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	req.Header.Set(api.ProtocolHeader, strconv.Itoa(api.ProtocolVersion))
+	if c.token != "" {
+		req.Header.Set(api.AuthHeader, api.AuthScheme+" "+c.token)
+	}
+	return c.client.Do(req)
+}
+
 // GetRoomID returns the current room ID
 func (c *RoomClient) GetRoomID() string {
 	return c.roomID
@@ -23,6 +23,9 @@ type ScreenManager struct {
 	inputLine     int // Line number for input
 	inputCol      int // Column position for input cursor (to restore after updates)
 
+	// Player lines as last drawn, so updates only redraw what changed
+	playerLines []string
+
 	// Config
 	maxLogLines int      // Maximum log lines to keep
 	logBuffer   []string // Rolling log buffer
@@ -111,6 +114,7 @@ const (
 // It calculates layout while drawing to ensure they stay in sync
 func (sm *ScreenManager) InitScreen(numPlayers int) {
 	sm.numPlayers = numPlayers
+	sm.playerLines = make([]string, numPlayers) // Drawn blank below
 
 	// Enter alternate screen buffer (like vi/less)
 	// This preserves the user's terminal history
@@ -201,6 +205,7 @@ func (sm *ScreenManager) InitScreen(numPlayers int) {
 }
 
 // UpdateProgress updates the progress section (top area)
+// Only the lines of players whose progress changed are redrawn.
 func (sm *ScreenManager) UpdateProgress(progress *api.RoomProgressResponse) {
 	sm.mu.Lock()
 	defer sm.mu.Unlock()
@@ -214,41 +219,26 @@ func (sm *ScreenManager) UpdateProgress(progress *api.RoomProgressResponse) {
 	// Build output buffer
 	output := ""
 
-	// Update each player line
+	// Update each changed player line
 	for i, player := range progress.Players {
+		info := progressLine(player)
+		if sm.playerLines[i] == info {
+			continue
+		}
+		sm.playerLines[i] = info
+
 		// Move to this player's line
 		lineNum := sm.progressStart + i
 		moveCursor := fmt.Sprintf(AnsiCursorPos, lineNum, 1)
 		output += moveCursor
 
-		// Clear line
+		// Clear line and draw content
 		output += AnsiClearLine
-
-		// Draw content
-		statusIcon := "🎮"
-		if player.Status == "won" {
-			statusIcon = "🏆"
-		} else if player.Status == "lost" {
-			statusIcon = "❌"
-		}
-
-		lastResult := ""
-		if player.LastGuess != nil {
-			lastResult = strings.Join(player.LastGuess.Results, "")
-		}
-
-		// Pad nickname to exactly 10 display columns for alignment
-		paddedNickname := padOrTruncate(player.Nickname, 10)
-
-		info := fmt.Sprintf("%s %s: Round %d/%d %s",
-			statusIcon, paddedNickname, player.CurrentRound, player.MaxRounds, lastResult)
-
-		// Pad to 58 display columns (60 - 2 borders)
-		// Format: "║{58 cols}║" - consistent with all other lines
-		info = padOrTruncate(info, 58)
-
 		output += fmt.Sprintf("║%s║", info)
 	}
+	if output == "" {
+		return
+	}
 
 	// Move cursor back to input position (line and column)
 	moveCursorToInput := fmt.Sprintf(AnsiCursorPos, sm.inputLine, sm.inputCol)
@@ -259,6 +249,31 @@ func (sm *ScreenManager) UpdateProgress(progress *api.RoomProgressResponse) {
 	os.Stdout.Sync()
 }
 
+// progressLine formats a player's progress line, without borders
+func progressLine(player api.PlayerProgress) string {
+	statusIcon := "🎮"
+	if player.Status == "won" {
+		statusIcon = "🏆"
+	} else if player.Status == "lost" {
+		statusIcon = "❌"
+	}
+
+	lastResult := ""
+	if player.LastGuess != nil {
+		lastResult = strings.Join(player.LastGuess.Results, "")
+	}
+
+	// Pad nickname to exactly 10 display columns for alignment
+	paddedNickname := padOrTruncate(player.Nickname, 10)
+
+	info := fmt.Sprintf("%s %s: Round %d/%d %s",
+		statusIcon, paddedNickname, player.CurrentRound, player.MaxRounds, lastResult)
+
+	// Pad to 58 display columns (60 - 2 borders)
+	// Format: "║{58 cols}║" - consistent with all other lines
+	return padOrTruncate(info, 58)
+}
+
 // FullRedraw redraws the entire screen (when layout changes)
 func (sm *ScreenManager) FullRedraw(progress *api.RoomProgressResponse) {
 	sm.mu.Lock()
@@ -279,28 +294,8 @@ func (sm *ScreenManager) fullRedrawLocked(progress *api.RoomProgressResponse) {
 		output += moveCursor
 		output += AnsiClearLine
 
-		statusIcon := "🎮"
-		if player.Status == "won" {
-			statusIcon = "🏆"
-		} else if player.Status == "lost" {
-			statusIcon = "❌"
-		}
-
-		lastResult := ""
-		if player.LastGuess != nil {
-			lastResult = strings.Join(player.LastGuess.Results, "")
-		}
-
-		// Pad nickname to exactly 10 display columns for alignment
-		paddedNickname := padOrTruncate(player.Nickname, 10)
-
-		info := fmt.Sprintf("%s %s: Round %d/%d %s",
-			statusIcon, paddedNickname, player.CurrentRound, player.MaxRounds, lastResult)
-
-		// Pad to 58 display columns (60 - 2 borders)
-		// Format: "║{58 cols}║" - consistent with all other lines
-		info = padOrTruncate(info, 58)
-
+		info := progressLine(player)
+		sm.playerLines[i] = info
 		output += fmt.Sprintf("║%s║", info)
 	}
 
@@ -0,0 +1,133 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/admin/wordle/pkg/api"
+	"github.com/gorilla/websocket"
+)
+
+// wsRetryInterval is how long the client long-polls after the WebSocket
+// fails before trying it again
+const wsRetryInterval = 30 * time.Second
+
+// NextProgress returns the next room progress update
+// It prefers the room WebSocket, where the server pushes every change, and
+// falls back to long polling (GetProgress) when the WebSocket is unavailable.
+// Like long polling, it may return progress that is not newer than version.
+//
+// Only progress uses the WebSocket. StartGame and MakeGuess stay on HTTP
+// even while it is open: their replies would arrive on the same stream as
+// progress, which NextProgress reads from another goroutine, and over HTTP
+// each action gets its own status, error code and Retry-After whether or not
+// the WebSocket is up.
+func (c *RoomClient) NextProgress(version int) (*api.RoomProgressResponse, error) {
+	conn, err := c.progressConn()
+	if err != nil {
+		return nil, err
+	}
+
+	if conn != nil {
+		progress, err := readProgress(conn)
+		if err == nil {
+			c.setProgress(progress)
+			return progress, nil
+		}
+		if errors.Is(err, ErrExpired) {
+			return nil, err
+		}
+		c.dropConn(conn)
+	}
+
+	return c.GetProgress(version)
+}
+
+// progressConn returns the room WebSocket, dialing it if needed
+// It returns nil (and no error) while the client is long polling.
+func (c *RoomClient) progressConn() (*websocket.Conn, error) {
+	c.wsMu.Lock()
+	defer c.wsMu.Unlock()
+
+	if c.ws != nil || time.Now().Before(c.wsRetryAt) {
+		return c.ws, nil
+	}
+
+	conn, err := c.dialProgress()
+	if errors.Is(err, ErrExpired) {
+		return nil, err
+	}
+	if err != nil {
+		c.wsRetryAt = time.Now().Add(wsRetryInterval)
+		return nil, nil
+	}
+	c.ws = conn
+	return conn, nil
+}
+
+// dialProgress opens the room WebSocket with the player token
+func (c *RoomClient) dialProgress() (*websocket.Conn, error) {
+	// http:// becomes ws:// and https:// becomes wss://
+	url := fmt.Sprintf("ws%s%s/room/%s/ws", strings.TrimPrefix(c.serverURL, "http"), api.APIVersionPrefix, c.roomID)
+
+	header := http.Header{}
+	header.Set(api.ProtocolHeader, strconv.Itoa(api.ProtocolVersion))
+	if c.token != "" {
+		header.Set(api.AuthHeader, api.AuthScheme+" "+c.token)
+	}
+
+	conn, resp, err := websocket.DefaultDialer.Dial(url, header)
+	if resp != nil && resp.StatusCode == http.StatusGone {
+		return nil, ErrExpired
+	}
+	return conn, err
+}
+
+// readProgress reads messages until the next progress update
+func readProgress(conn *websocket.Conn) (*api.RoomProgressResponse, error) {
+	for {
+		var msg api.WSServerMessage
+		if err := conn.ReadJSON(&msg); err != nil {
+			return nil, err
+		}
+		switch msg.Type {
+		case api.WSProgress:
+			if msg.Progress != nil {
+				return msg.Progress, nil
+			}
+		case api.WSExpired:
+			return nil, ErrExpired
+		}
+	}
+}
+
+// dropConn closes a failed WebSocket so the client falls back to long polling
+func (c *RoomClient) dropConn(conn *websocket.Conn) {
+	c.wsMu.Lock()
+	defer c.wsMu.Unlock()
+
+	conn.Close()
+	if c.ws == conn {
+		c.ws = nil
+		c.wsRetryAt = time.Now().Add(wsRetryInterval)
+	}
+}
+
+// Close closes the room WebSocket, if open, unblocking NextProgress
+func (c *RoomClient) Close() error {
+	c.wsMu.Lock()
+	defer c.wsMu.Unlock()
+
+	if c.ws == nil {
+		return nil
+	}
+	err := c.ws.Close()
+	c.ws = nil
+	// Stay on long polling; the room is being left
+	c.wsRetryAt = time.Now().Add(wsRetryInterval)
+	return err
+}
@@ -0,0 +1,98 @@
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/admin/wordle/pkg/api"
+	"github.com/gorilla/websocket"
+)
+
+// transportRoom is the room the fake server in TestNextProgress serves
+const transportRoom = "ROOM42"
+
+func TestNextProgress(t *testing.T) {
+	// Each progress says where it came from in its message
+	progress := func(source string) *api.RoomProgressResponse {
+		return &api.RoomProgressResponse{RoomID: transportRoom, Version: 1, Message: source}
+	}
+
+	tests := []struct {
+		name      string
+		ws        string   // How the WebSocket answers: refuse, gone, drop (after one progress) or expired
+		steps     []string // Expected source of each NextProgress: ws, poll or expired; retry lets the client dial again
+		wantDials int32
+	}{
+		{"dial fails", "refuse", []string{"poll", "poll", "retry", "poll"}, 2},
+		{"room gone when dialing", "gone", []string{"expired"}, 1},
+		{"dropped connection", "drop", []string{"ws", "poll", "poll", "retry", "ws"}, 2},
+		{"room expired", "expired", []string{"expired"}, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var dials atomic.Int32
+			mux := http.NewServeMux()
+			mux.HandleFunc("/v1/room/"+transportRoom+"/ws", func(w http.ResponseWriter, r *http.Request) {
+				dials.Add(1)
+				if r.Header.Get(api.AuthHeader) != api.AuthScheme+" secret" {
+					t.Errorf("WebSocket dialed with Authorization %q", r.Header.Get(api.AuthHeader))
+				}
+				switch tt.ws {
+				case "refuse":
+					http.NotFound(w, r)
+					return
+				case "gone":
+					w.WriteHeader(http.StatusGone)
+					return
+				}
+				conn, err := (&websocket.Upgrader{}).Upgrade(w, r, nil)
+				if err != nil {
+					return
+				}
+				defer conn.Close()
+				if tt.ws == "expired" {
+					conn.WriteJSON(api.WSServerMessage{Type: api.WSExpired})
+					return
+				}
+				conn.WriteJSON(api.WSServerMessage{Type: api.WSProgress, Progress: progress("ws")})
+			})
+			mux.HandleFunc("/room/"+transportRoom+"/progress", func(w http.ResponseWriter, r *http.Request) {
+				json.NewEncoder(w).Encode(progress("poll"))
+			})
+			srv := httptest.NewServer(mux)
+			defer srv.Close()
+
+			c := NewRoomClient(srv.URL)
+			c.roomID = transportRoom
+			c.token = "secret"
+			defer c.Close()
+
+			for i, step := range tt.steps {
+				if step == "retry" {
+					c.wsMu.Lock()
+					c.wsRetryAt = time.Time{}
+					c.wsMu.Unlock()
+					continue
+				}
+				got, err := c.NextProgress(0)
+				switch {
+				case step == "expired":
+					if !errors.Is(err, ErrExpired) {
+						t.Errorf("step %d: NextProgress() = %+v, %v; want ErrExpired", i, got, err)
+					}
+				case err != nil || got.Message != step:
+					t.Errorf("step %d: NextProgress() = %+v, %v; want progress from %s", i, got, err, step)
+				}
+			}
+			if got := dials.Load(); got != tt.wantDials {
+				t.Errorf("WebSocket dialed %d times, want %d", got, tt.wantDials)
+			}
+		})
+	}
+}
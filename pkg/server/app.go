@@ -1,10 +1,16 @@
 package server
 
 import (
+	"context"
 	"fmt"
-	"log"
+	"log/slog"
+	"net"
+	"net/http"
+	"os"
+	"time"
 
 	"github.com/admin/wordle/internal/config"
+	"github.com/admin/wordle/pkg/api"
 	"github.com/gin-gonic/gin"
 )
 
@@ -15,47 +21,105 @@ type App struct {
 	port   string
 }
 
-// NewApp creates a new server application
-func NewApp(cfg *config.Config, port string) *App {
-	// Set gin to debug mode to see more details
-	gin.SetMode(gin.DebugMode)
+// NewApp creates a new server application using the configured storage backend
+func NewApp(cfg *config.Config, port string) (*App, error) {
+	// Config modes are gin's; debug mode prints routes and gin warnings
+	gin.SetMode(cfg.Mode)
 
-	// Create router with logger and recovery middleware
+	logger := NewLogger(cfg, os.Stderr)
+	slog.SetDefault(logger)
+
+	// Create router with request ID, logging and recovery middleware
 	router := gin.New()
-	router.Use(gin.Logger())   // Add logger middleware to print requests
-	router.Use(gin.Recovery()) // Add recovery middleware to handle panics
+	router.Use(RequestID())
+	router.Use(AccessLog(logger))
+	router.Use(Recovery(logger))
+
+	// Only trusted proxies may set the client IP that rate limits key on
+	if err := router.SetTrustedProxies(cfg.TrustedProxies); err != nil {
+		return nil, fmt.Errorf("invalid trusted_proxies: %w", err)
+	}
 
-	return &App{
-		server: NewServer(cfg),
+	store, err := OpenStore(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open storage: %w", err)
+	}
+	server, err := NewServer(cfg, store)
+	if err != nil {
+		store.Close()
+		return nil, err
+	}
+	router.Use(server.MetricsMiddleware()) // Each server keeps its own metrics
+
+	app := &App{
+		server: server,
 		router: router,
 		port:   port,
 	}
+	app.registerRoutes()
+	return app, nil
+}
+
+// registerRoutes registers every route on the router
+func (a *App) registerRoutes() {
+	// The API is served under /v1, and at its original paths for
+	// existing clients, rate limited per client IP and player token
+	v1 := a.router.Group(api.APIVersionPrefix, a.server.RateLimit())
+	a.registerAPI(v1)
+	a.registerAPI(a.router.Group("/", a.server.RateLimit()))
+	v1.GET("/openapi.json", a.server.HandleOpenAPI)
+
+	// Server maintenance, for operators rather than clients, is unversioned
+	a.router.GET("/metrics", a.server.HandleMetrics)
+	a.router.GET("/healthz", a.server.HandleHealthz)
+	a.router.GET("/readyz", a.server.HandleReadyz)
 }
 
-// Start starts the HTTP server
-func (a *App) Start() error {
-	// Register single-player game routes (Task 2)
-	a.router.POST("/game/new", a.server.HandleNewGame)
-	a.router.POST("/game/:id/guess", a.server.HandleGuess)
-	a.router.GET("/game/:id/status", a.server.HandleStatus)
-
-	// Register multi-player room routes (Task 4)
-	a.router.POST("/room/create", a.server.HandleCreateRoom)
-	a.router.POST("/room/:id/join", a.server.HandleJoinRoom)
-	a.router.POST("/room/:id/leave", a.server.HandleLeaveRoom)
-	a.router.POST("/room/:id/start", a.server.HandleStartRoom)
-	a.router.POST("/room/:id/guess", a.server.HandleRoomGuess)
-	a.router.GET("/room/:id/progress", a.server.HandleRoomProgress)
-	a.router.GET("/room/:id/status", a.server.HandleRoomStatus)
-	a.router.GET("/room/list", a.server.HandleListRooms)
+// registerAPI registers the client API routes on r
+func (a *App) registerAPI(r gin.IRoutes) {
+	// Single-player game routes (Task 2)
+	r.POST("/game/new", a.server.HandleNewGame)
+	r.POST("/game/:id/guess", a.server.HandleGuess)
+	r.GET("/game/:id/status", a.server.HandleStatus)
+	r.GET("/game/:id/suggest", a.server.HandleSuggest)
+	r.GET("/game/:id/candidates", a.server.HandleCandidates)
+	r.POST("/daily/new", a.server.HandleNewDailyGame)
 
+	// Multi-player room routes (Task 4)
+	r.POST("/room/create", a.server.HandleCreateRoom)
+	r.POST("/room/:id/join", a.server.HandleJoinRoom)
+	r.POST("/room/:id/leave", a.server.HandleLeaveRoom)
+	r.POST("/room/:id/start", a.server.HandleStartRoom)
+	r.POST("/room/:id/guess", a.server.HandleRoomGuess)
+	r.GET("/room/:id/progress", a.server.HandleRoomProgress)
+	r.GET("/room/:id/ws", a.server.HandleRoomWebSocket)
+	r.GET("/room/:id/events", a.server.HandleRoomEvents)
+	r.GET("/room/:id/status", a.server.HandleRoomStatus)
+	r.GET("/room/:id/candidates", a.server.HandleRoomCandidates)
+	r.GET("/room/list", a.server.HandleListRooms)
+
+	// Stats
+	r.GET("/stats/janitor", a.server.HandleJanitorStats)
+}
+
+// Start runs the HTTP server until ctx is done, then shuts it down
+// Shutdown stops new games and rooms and fails /readyz for the configured
+// drain delay, so load balancers stop sending traffic, then releases waiting
+// clients with a final "server shutting down" progress, drains requests for
+// up to the configured shutdown timeout and flushes the store.
+func (a *App) Start(ctx context.Context) error {
 	// Print startup info
 	addr := ":" + a.port
 	fmt.Printf("Wordle Server starting on http://localhost%s\n", addr)
+	fmt.Printf("\nAPI routes are served under %s and at the paths below\n", api.APIVersionPrefix)
+	fmt.Printf("  GET  %s/openapi.json     - OpenAPI 3 document\n", api.APIVersionPrefix)
 	fmt.Println("\n=== Single-Player API (Task 2) ===")
 	fmt.Println("  POST /game/new            - Create new game")
 	fmt.Println("  POST /game/:id/guess      - Submit a guess")
 	fmt.Println("  GET  /game/:id/status     - Get game status")
+	fmt.Println("  GET  /game/:id/suggest    - Get solver suggestions")
+	fmt.Println("  GET  /game/:id/candidates - Count remaining candidates")
+	fmt.Println("  POST /daily/new           - Start today's daily puzzle")
 	fmt.Println("\n=== Multi-Player API (Task 4) ===")
 	fmt.Println("  POST /room/create         - Create a room")
 	fmt.Println("  POST /room/:id/join       - Join a room")
@@ -63,11 +127,90 @@ func (a *App) Start() error {
 	fmt.Println("  POST /room/:id/start      - Start the game (host only)")
 	fmt.Println("  POST /room/:id/guess      - Submit a guess")
 	fmt.Println("  GET  /room/:id/progress   - Get live progress (long polling)")
+	fmt.Println("  GET  /room/:id/ws         - Live progress and actions (WebSocket)")
+	fmt.Println("  GET  /room/:id/events     - Live room events (Server-Sent Events)")
 	fmt.Println("  GET  /room/:id/status     - Get room status")
+	fmt.Println("  GET  /room/:id/candidates - Count your remaining candidates")
 	fmt.Println("  GET  /room/list           - List available rooms")
+	fmt.Println("\n=== Stats ===")
+	fmt.Println("  GET  /stats/janitor       - Games and rooms reclaimed by the janitor")
+	fmt.Println("\n=== Maintenance ===")
+	fmt.Println("  GET  /metrics             - Prometheus metrics")
+	fmt.Println("  GET  /healthz             - Liveness probe")
+	fmt.Println("  GET  /readyz              - Readiness probe with individual checks")
 	fmt.Println()
 
+	ln, err := net.Listen("tcp", addr)
+	if err != nil {
+		a.server.store.Close()
+		return err
+	}
+	return a.serve(ctx, ln)
+}
+
+// serve runs the HTTP server on ln until ctx is done, then shuts it down as
+// described on Start
+func (a *App) serve(ctx context.Context, ln net.Listener) error {
+	// Remove abandoned games and rooms in the background
+	janitorCtx, stopJanitor := context.WithCancel(ctx)
+	janitorDone := make(chan struct{})
+	go func() {
+		defer close(janitorDone)
+		a.server.RunJanitor(janitorCtx, a.server.config.JanitorInterval)
+	}()
+	defer func() {
+		stopJanitor()
+		<-janitorDone
+	}()
+
+	cfg := a.server.config
+	srv := &http.Server{
+		Handler:      a.router,
+		ReadTimeout:  cfg.ReadTimeout,
+		WriteTimeout: cfg.WriteTimeout,
+		IdleTimeout:  cfg.IdleTimeout,
+	}
+
 	// Start server
-	log.Printf("Server listening on port %s", a.port)
-	return a.router.Run(addr)
+	slog.Info("server listening", "port", a.port, "storage", cfg.Storage, "mode", cfg.Mode)
+	serveErr := make(chan error, 1)
+	go func() {
+		serveErr <- srv.Serve(ln)
+	}()
+
+	select {
+	case err := <-serveErr:
+		a.server.store.Close()
+		return err
+	case <-ctx.Done():
+	}
+
+	// Keep serving while /readyz reports draining, so traffic moves away
+	// before the listener closes
+	a.server.BeginShutdown()
+	if cfg.DrainDelay > 0 {
+		slog.Info("draining", "delay", cfg.DrainDelay)
+		time.Sleep(cfg.DrainDelay)
+	}
+
+	slog.Info("shutting down", "timeout", cfg.ShutdownTimeout)
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), cfg.ShutdownTimeout)
+	defer cancel()
+
+	// Waiting clients would hold Shutdown for their whole wait; everything
+	// else finishes normally
+	a.server.ReleaseWaiters()
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		slog.Warn("shutdown deadline passed, closing open connections", "err", err)
+		srv.Close()
+	}
+
+	// Stop the janitor before the store is closed under it
+	stopJanitor()
+	<-janitorDone
+	if err := a.server.Close(shutdownCtx); err != nil {
+		return fmt.Errorf("failed to flush storage: %w", err)
+	}
+	slog.Info("server stopped")
+	return nil
 }
@@ -0,0 +1,234 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/admin/wordle/internal/config"
+	"github.com/admin/wordle/pkg/api"
+)
+
+// testAnswer is the only word in the test word list, so every game's answer
+const testAnswer = "CRANE"
+
+// newTestApp returns an app with in-memory storage and no rate limits,
+// after applying configure, if given, to its configuration
+func newTestApp(t *testing.T, configure func(cfg *config.Config)) *App {
+	t.Helper()
+	cfg := config.DefaultConfig()
+	cfg.WordList = []string{testAnswer}
+	cfg.LogLevel = "error"
+	cfg.RateLimit = -1
+	cfg.TokenRateLimit = -1
+	if configure != nil {
+		configure(cfg)
+	}
+
+	app, err := NewApp(cfg, "0")
+	if err != nil {
+		t.Fatalf("NewApp() error = %v", err)
+	}
+	t.Cleanup(func() { app.server.store.Close() })
+	return app
+}
+
+// serve sends a request to app and returns the response
+// A non-nil body is sent as JSON; header holds extra request headers.
+func serve(t *testing.T, app *App, method, path string, body any, header http.Header) *httptest.ResponseRecorder {
+	t.Helper()
+	var data []byte
+	if body != nil {
+		var err error
+		if data, err = json.Marshal(body); err != nil {
+			t.Fatalf("Marshal() error = %v", err)
+		}
+	}
+
+	req := httptest.NewRequest(method, path, bytes.NewReader(data))
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	for key, values := range header {
+		req.Header[key] = values
+	}
+	rec := httptest.NewRecorder()
+	app.router.ServeHTTP(rec, req)
+	return rec
+}
+
+// decode parses a JSON response body into v
+func decode(t *testing.T, rec *httptest.ResponseRecorder, v any) {
+	t.Helper()
+	if err := json.Unmarshal(rec.Body.Bytes(), v); err != nil {
+		t.Fatalf("response %q is not JSON: %v", rec.Body.String(), err)
+	}
+}
+
+// bearer returns the Authorization header for a player token
+func bearer(token string) http.Header {
+	return http.Header{"Authorization": {"Bearer " + token}}
+}
+
+// v2 returns the headers of a protocol version 2 client, with its token if
+// it has one
+func v2(token string) http.Header {
+	header := http.Header{api.ProtocolHeader: {strconv.Itoa(api.ProtocolVersion)}}
+	if token != "" {
+		header.Set(api.AuthHeader, api.AuthScheme+" "+token)
+	}
+	return header
+}
+
+// createRoom creates a room and returns the host's response
+func createRoom(t *testing.T, app *App) api.CreateRoomResponse {
+	t.Helper()
+	var room api.CreateRoomResponse
+	decode(t, serve(t, app, http.MethodPost, "/v1/room/create", api.CreateRoomRequest{Nickname: "host"}, v2("")), &room)
+	return room
+}
+
+// joinRoom adds a guest to roomID and returns the guest's response
+func joinRoom(t *testing.T, app *App, roomID string) api.JoinRoomResponse {
+	t.Helper()
+	var guest api.JoinRoomResponse
+	decode(t, serve(t, app, http.MethodPost, "/v1/room/"+roomID+"/join", api.JoinRoomRequest{Nickname: "guest"}, v2("")), &guest)
+	return guest
+}
+
+// wantError fails unless rec is an api.ErrorResponse with status and code
+func wantError(t *testing.T, rec *httptest.ResponseRecorder, status int, code string) {
+	t.Helper()
+	var resp api.ErrorResponse
+	decode(t, rec, &resp)
+	if rec.Code != status || resp.Code != code {
+		t.Errorf("response = %d %+v, want %d %s", rec.Code, resp, status, code)
+	}
+}
+
+// ginParam matches gin path parameters such as :id
+var ginParam = regexp.MustCompile(`:(\w+)`)
+
+// TestOpenAPIPathsMatchRoutes fails when the OpenAPI document and the routes
+// registered under /v1 disagree on paths or methods
+func TestOpenAPIPathsMatchRoutes(t *testing.T) {
+	app := newTestApp(t, nil)
+
+	var routes []string
+	for _, route := range app.router.Routes() {
+		path, ok := strings.CutPrefix(route.Path, api.APIVersionPrefix)
+		if !ok {
+			continue
+		}
+		path = ginParam.ReplaceAllString(path, "{$1}")
+		routes = append(routes, route.Method+" "+path)
+	}
+	slices.Sort(routes)
+
+	var doc struct {
+		Paths map[string]map[string]json.RawMessage `json:"paths"`
+	}
+	if err := json.Unmarshal(api.OpenAPISpec, &doc); err != nil {
+		t.Fatalf("openapi.json is not valid JSON: %v", err)
+	}
+	var documented []string
+	for path, item := range doc.Paths {
+		for method := range item {
+			documented = append(documented, strings.ToUpper(method)+" "+path)
+		}
+	}
+	slices.Sort(documented)
+
+	for _, route := range routes {
+		if !slices.Contains(documented, route) {
+			t.Errorf("route %s is not in openapi.json", route)
+		}
+	}
+	for _, op := range documented {
+		if !slices.Contains(routes, op) {
+			t.Errorf("openapi.json documents %s, which is not a route", op)
+		}
+	}
+}
+
+// TestShutdown checks that shutdown reports draining while still serving,
+// and only then releases waiting clients
+func TestShutdown(t *testing.T) {
+	app := newTestApp(t, func(cfg *config.Config) {
+		cfg.DrainDelay = 500 * time.Millisecond
+	})
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	base := "http://" + ln.Addr().String()
+	ctx, stop := context.WithCancel(t.Context())
+	served := make(chan error, 1)
+	go func() { served <- app.serve(ctx, ln) }()
+
+	var room api.CreateRoomResponse
+	decode(t, serve(t, app, http.MethodPost, "/v1/room/create", api.CreateRoomRequest{Nickname: "host"}, v2("")), &room)
+	r, _ := app.server.roomManager.GetRoom(room.RoomID)
+	r.mu.RLock()
+	version := r.Version
+	r.mu.RUnlock()
+
+	type result struct {
+		progress api.RoomProgressResponse
+		err      error
+	}
+	polled := make(chan result, 1)
+	go func() {
+		var res result
+		resp, err := http.Get(fmt.Sprintf("%s/v1/room/%s/progress?version=%d", base, room.RoomID, version))
+		if err == nil {
+			defer resp.Body.Close()
+			err = json.NewDecoder(resp.Body).Decode(&res.progress)
+		}
+		res.err = err
+		polled <- res
+	}()
+	for waiting := false; !waiting; time.Sleep(10 * time.Millisecond) {
+		r.mu.RLock()
+		waiting = r.listeners > 0
+		r.mu.RUnlock()
+	}
+
+	stop()
+	time.Sleep(100 * time.Millisecond)
+
+	// During the drain delay the server answers, but is not ready
+	for path, want := range map[string]int{"/readyz": http.StatusServiceUnavailable, "/v1/room/list": http.StatusOK} {
+		resp, err := http.Get(base + path)
+		if err != nil {
+			t.Fatalf("GET %s while draining: %v", path, err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != want {
+			t.Errorf("GET %s while draining = %d, want %d", path, resp.StatusCode, want)
+		}
+	}
+	select {
+	case <-polled:
+		t.Error("long poll released before the drain delay ended")
+	default:
+	}
+
+	res := <-polled
+	if res.err != nil || res.progress.Message != ErrShuttingDown.Error() {
+		t.Errorf("released long poll = %+v, %v; want the shutdown message", res.progress, res.err)
+	}
+	if err := <-served; err != nil {
+		t.Errorf("serve() error = %v", err)
+	}
+}
@@ -0,0 +1,136 @@
+package server
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/admin/wordle/internal/game"
+	"github.com/admin/wordle/pkg/api"
+	"github.com/gin-gonic/gin"
+)
+
+// Errors returned for games and rooms that cannot be found or used
+var (
+	ErrGameNotFound           = errors.New("game not found")
+	ErrRoomNotFound           = errors.New("room not found")
+	ErrPlayerMismatch         = errors.New("player token does not match player ID")
+	ErrSuggestionsDisabled    = errors.New("suggestions are disabled on this server")
+	ErrSuggestionsUnsupported = errors.New("suggestions are not available for multi-board games")
+	ErrDailyOptions           = errors.New("daily puzzle is classic mode with a single board")
+)
+
+// errorKinds gives the HTTP status and api error code of each known error,
+// checked in order with errors.Is
+// Errors not listed are reported as 400 with api.CodeInvalidRequest.
+var errorKinds = []struct {
+	err    error
+	status int
+	code   string
+}{
+	// Rejected guesses
+	{game.ErrInvalidWord, http.StatusBadRequest, api.CodeInvalidWord},
+	{game.ErrNotInWordList, http.StatusBadRequest, api.CodeNotInDictionary},
+	{game.ErrGameOver, http.StatusBadRequest, api.CodeGameOver},
+	{ErrPlayerFinished, http.StatusBadRequest, api.CodeGameOver},
+
+	// Lookups and authentication
+	{ErrGameNotFound, http.StatusNotFound, api.CodeNotFound},
+	{ErrRoomNotFound, http.StatusNotFound, api.CodeNotFound},
+	{ErrGameExpired, http.StatusGone, api.CodeExpired},
+	{ErrRoomExpired, http.StatusGone, api.CodeExpired},
+	{ErrMissingToken, http.StatusUnauthorized, api.CodeUnauthorized},
+	{ErrInvalidToken, http.StatusUnauthorized, api.CodeUnauthorized},
+	{ErrPlayerMismatch, http.StatusForbidden, api.CodeForbidden},
+
+	// Room actions
+	{ErrRoomFull, http.StatusBadRequest, api.CodeRoomFull},
+	{ErrRoomClosed, http.StatusBadRequest, api.CodeRoomClosed},
+	{ErrAlreadyInRoom, http.StatusBadRequest, api.CodeAlreadyInRoom},
+	{ErrNotInRoom, http.StatusBadRequest, api.CodeNotInRoom},
+	{ErrNotHost, http.StatusBadRequest, api.CodeNotHost},
+	{ErrAlreadyStarted, http.StatusBadRequest, api.CodeAlreadyStarted},
+	{ErrNotEnoughPlayers, http.StatusBadRequest, api.CodeNotEnoughPlayers},
+	{ErrRoomNotPlaying, http.StatusBadRequest, api.CodeRoomNotPlaying},
+
+	// Server features and state
+	{ErrSuggestionsDisabled, http.StatusForbidden, api.CodeSuggestionsDisabled},
+	{ErrSuggestionsUnsupported, http.StatusBadRequest, api.CodeUnsupported},
+	{ErrDailyOptions, http.StatusBadRequest, api.CodeUnsupported},
+	{ErrShuttingDown, http.StatusServiceUnavailable, api.CodeShuttingDown},
+
+	// Rate limits and caps
+	{ErrRateLimited, http.StatusTooManyRequests, api.CodeRateLimited},
+	{ErrTooManyGames, http.StatusTooManyRequests, api.CodeTooManyGames},
+	{ErrTooManyRooms, http.StatusTooManyRequests, api.CodeTooManyRooms},
+	{ErrTooManyListeners, http.StatusTooManyRequests, api.CodeTooManyListeners},
+}
+
+// errorCode returns the HTTP status and api error code for err
+func errorCode(err error) (int, string) {
+	var hardMode *game.HardModeError
+	if errors.As(err, &hardMode) {
+		return http.StatusBadRequest, api.CodeHardMode
+	}
+	for _, kind := range errorKinds {
+		if errors.Is(err, kind.err) {
+			return kind.status, kind.code
+		}
+	}
+	return http.StatusBadRequest, api.CodeInvalidRequest
+}
+
+// errorDetails returns the structured details of err, if it has any
+func errorDetails(err error) map[string]string {
+	var hardMode *game.HardModeError
+	if !errors.As(err, &hardMode) {
+		return nil
+	}
+	details := map[string]string{"letter": hardMode.Letter}
+	if hardMode.Position >= 0 {
+		details["position"] = strconv.Itoa(hardMode.Position + 1) // 1-based, as in the message
+	}
+	return details
+}
+
+// writeError writes err as an api.ErrorResponse with its status and code,
+// and a Retry-After header if it carries one
+func writeError(c *gin.Context, err error) {
+	var retry *retryError
+	if errors.As(err, &retry) {
+		c.Header("Retry-After", retrySeconds(retry.after))
+	}
+	status, code := errorCode(err)
+	c.JSON(status, api.ErrorResponse{
+		Error:   err.Error(),
+		Code:    code,
+		Details: errorDetails(err),
+	})
+}
+
+// badRequest writes a 400 for a malformed body or query parameter
+func badRequest(c *gin.Context, message string) {
+	c.JSON(http.StatusBadRequest, api.ErrorResponse{
+		Error: message,
+		Code:  api.CodeInvalidRequest,
+	})
+}
+
+// internalError writes a 500 for a failure on the server's side
+func internalError(c *gin.Context, message string) {
+	c.JSON(http.StatusInternalServerError, api.ErrorResponse{
+		Error: message,
+		Code:  api.CodeInternal,
+	})
+}
+
+// wsError returns a WebSocket error message for err
+func wsError(err error) api.WSServerMessage {
+	_, code := errorCode(err)
+	return api.WSServerMessage{
+		Type:    api.WSError,
+		Error:   err.Error(),
+		Code:    code,
+		Details: errorDetails(err),
+	}
+}
@@ -0,0 +1,87 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/admin/wordle/internal/game"
+	"github.com/admin/wordle/pkg/api"
+	"github.com/gin-gonic/gin"
+)
+
+func TestErrorCode(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+		wantCode   string
+	}{
+		{"invalid word", fmt.Errorf("%w: CR4NE", game.ErrInvalidWord), http.StatusBadRequest, api.CodeInvalidWord},
+		{"not in word list", game.ErrNotInWordList, http.StatusBadRequest, api.CodeNotInDictionary},
+		{"hard mode", fmt.Errorf("guess rejected: %w", &game.HardModeError{Letter: "R", Position: 1}), http.StatusBadRequest, api.CodeHardMode},
+		{"player finished", ErrPlayerFinished, http.StatusBadRequest, api.CodeGameOver},
+		{"expired room", ErrRoomExpired, http.StatusGone, api.CodeExpired},
+		{"invalid token", ErrInvalidToken, http.StatusUnauthorized, api.CodeUnauthorized},
+		{"player mismatch", ErrPlayerMismatch, http.StatusForbidden, api.CodeForbidden},
+		{"not host", ErrNotHost, http.StatusBadRequest, api.CodeNotHost},
+		{"game cap", retryAfter(ErrTooManyGames, time.Minute), http.StatusTooManyRequests, api.CodeTooManyGames},
+		{"shutting down", ErrShuttingDown, http.StatusServiceUnavailable, api.CodeShuttingDown},
+		{"unknown", errors.New("something else"), http.StatusBadRequest, api.CodeInvalidRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status, code := errorCode(tt.err)
+			if status != tt.wantStatus || code != tt.wantCode {
+				t.Errorf("errorCode(%v) = %d, %s; want %d, %s", tt.err, status, code, tt.wantStatus, tt.wantCode)
+			}
+		})
+	}
+
+	// No entry is hidden by an earlier one that also matches it
+	for _, kind := range errorKinds {
+		if status, code := errorCode(kind.err); status != kind.status || code != kind.code {
+			t.Errorf("errorCode(%v) = %d, %s; want %d, %s", kind.err, status, code, kind.status, kind.code)
+		}
+	}
+}
+
+func TestWriteError(t *testing.T) {
+	tests := []struct {
+		name           string
+		err            error
+		wantDetails    map[string]string
+		wantRetryAfter string
+	}{
+		{"plain", ErrRoomFull, nil, ""},
+		{"hard mode hit", &game.HardModeError{Letter: "R", Position: 1}, map[string]string{"letter": "R", "position": "2"}, ""},
+		{"hard mode present", &game.HardModeError{Letter: "E", Position: -1}, map[string]string{"letter": "E"}, ""},
+		{"retry", retryAfter(ErrRateLimited, 1500*time.Millisecond), nil, "2"},
+		{"retry under a second", retryAfter(ErrRateLimited, time.Millisecond), nil, "1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(rec)
+			writeError(c, tt.err)
+
+			var resp api.ErrorResponse
+			if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+				t.Fatalf("response %q is not JSON: %v", rec.Body, err)
+			}
+			if resp.Error != tt.err.Error() || !maps.Equal(resp.Details, tt.wantDetails) {
+				t.Errorf("response = %+v, want message %q and details %v", resp, tt.err, tt.wantDetails)
+			}
+			if got := rec.Header().Get("Retry-After"); got != tt.wantRetryAfter {
+				t.Errorf("Retry-After = %q, want %q", got, tt.wantRetryAfter)
+			}
+		})
+	}
+}
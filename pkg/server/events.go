@@ -0,0 +1,100 @@
+package server
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/admin/wordle/pkg/api"
+	"github.com/gin-contrib/sse"
+	"github.com/gin-gonic/gin"
+)
+
+// sseKeepAlive is how often an idle event stream sends a comment, so
+// proxies do not close it
+const sseKeepAlive = 15 * time.Second
+
+// HandleRoomEvents streams room changes as Server-Sent Events
+// Each event's ID is the room version. A new stream starts with a progress
+// snapshot; a reconnecting client sends Last-Event-ID (or ?last_event_id=)
+// and gets only the events it missed, or a fresh snapshot if the room no
+// longer has them.
+func (s *Server) HandleRoomEvents(c *gin.Context) {
+	room, ok := s.findRoom(c, c.Param("id"))
+	if !ok || !s.addRoomListener(c, room) {
+		return
+	}
+	defer room.removeListener()
+
+	version := -1
+	lastEventID := c.GetHeader("Last-Event-ID")
+	if lastEventID == "" {
+		lastEventID = c.Query("last_event_id")
+	}
+	if v, err := strconv.Atoi(lastEventID); err == nil && v >= 0 {
+		version = v
+	}
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no") // Disable proxy buffering
+	c.Status(http.StatusOK)
+
+	// The stream outlives the server's write timeout
+	http.NewResponseController(c.Writer).SetWriteDeadline(time.Time{})
+
+	ctx, release := s.waitContext(c)
+	defer release()
+	for {
+		if room.IsExpired() {
+			c.Render(-1, sse.Event{
+				Event: api.EventExpired,
+				Data:  api.RoomEvent{Type: api.EventExpired, Version: version, Timestamp: time.Now().Unix()},
+			})
+			c.Writer.Flush()
+			return
+		}
+
+		events, complete := room.EventsSince(version)
+		if version < 0 || !complete {
+			progress := room.GetProgress()
+			c.Render(-1, sse.Event{
+				Id:    strconv.Itoa(progress.Version),
+				Event: api.EventProgress,
+				Data:  progress,
+			})
+			version = progress.Version
+		}
+		for _, event := range events {
+			c.Render(-1, sse.Event{
+				Id:    strconv.Itoa(event.Version),
+				Event: event.Type,
+				Data:  event,
+			})
+			version = event.Version
+		}
+		c.Writer.Flush()
+
+		waitCtx, cancel := context.WithTimeout(ctx, sseKeepAlive)
+		changed := room.WaitForUpdate(waitCtx, version)
+		cancel()
+		if ctx.Err() != nil {
+			if s.ShuttingDown() {
+				c.Render(-1, sse.Event{
+					Id:    strconv.Itoa(version),
+					Event: api.EventProgress,
+					Data:  shutdownProgress(room.GetProgress()),
+				})
+				c.Writer.Flush()
+			}
+			// Otherwise the client disconnected
+			return
+		}
+		if !changed {
+			c.Writer.WriteString(": keep-alive\n\n")
+			c.Writer.Flush()
+		}
+	}
+}
@@ -0,0 +1,105 @@
+package server
+
+import (
+	"bufio"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/admin/wordle/pkg/api"
+)
+
+// sseEvent is the ID and name of one Server-Sent Event
+type sseEvent struct {
+	id   string
+	name string
+}
+
+// readEvents reads n events from an event stream
+func readEvents(t *testing.T, scanner *bufio.Scanner, n int) []sseEvent {
+	t.Helper()
+	var events []sseEvent
+	var event sseEvent
+	for len(events) < n && scanner.Scan() {
+		line := scanner.Text()
+		switch {
+		case strings.HasPrefix(line, "id:"):
+			event.id = strings.TrimSpace(strings.TrimPrefix(line, "id:"))
+		case strings.HasPrefix(line, "event:"):
+			event.name = strings.TrimSpace(strings.TrimPrefix(line, "event:"))
+		case line == "" && event.name != "":
+			events = append(events, event)
+			event = sseEvent{}
+		}
+	}
+	if len(events) < n {
+		t.Fatalf("stream ended after %v, want %d events: %v", events, n, scanner.Err())
+	}
+	return events
+}
+
+func TestRoomEventsResume(t *testing.T) {
+	// The room reaches version 3: joined (1), started (2), host guessed (3)
+	snapshot := []sseEvent{{"3", api.EventProgress}}
+	tests := []struct {
+		name     string
+		query    string
+		header   string // Last-Event-ID
+		truncate bool   // Drop the oldest event from the room's log
+		want     []sseEvent
+	}{
+		{"new stream", "", "", false, snapshot},
+		{"resume", "", "1", false, []sseEvent{{"2", api.EventGameStarted}, {"3", api.EventGuessMade}}},
+		{"resume by query", "?last_event_id=2", "", false, []sseEvent{{"3", api.EventGuessMade}}},
+		{"up to date", "", "3", false, nil},
+		{"missed events dropped from the log", "", "0", true, snapshot},
+		{"version ahead of the room", "", "9", false, snapshot},
+		{"malformed ID", "", "latest", false, snapshot},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := newTestApp(t, nil)
+			host := createRoom(t, app)
+			guest := joinRoom(t, app, host.RoomID)
+			roomPath := "/v1/room/" + host.RoomID
+			serve(t, app, http.MethodPost, roomPath+"/start", nil, v2(host.Token))
+			serve(t, app, http.MethodPost, roomPath+"/guess", api.RoomGuessRequest{Guess: "SLATE"}, v2(host.Token))
+			if tt.truncate {
+				r, _ := app.server.roomManager.GetRoom(host.RoomID)
+				r.mu.Lock()
+				r.events = r.events[1:]
+				r.mu.Unlock()
+			}
+
+			srv := httptest.NewServer(app.router)
+			defer srv.Close()
+			ctx, cancel := context.WithTimeout(t.Context(), 5*time.Second)
+			defer cancel()
+			req, _ := http.NewRequestWithContext(ctx, http.MethodGet, srv.URL+roomPath+"/events"+tt.query, nil)
+			if tt.header != "" {
+				req.Header.Set("Last-Event-ID", tt.header)
+			}
+			resp, err := http.DefaultClient.Do(req)
+			if err != nil {
+				t.Fatalf("GET events: %v", err)
+			}
+			defer resp.Body.Close()
+			scanner := bufio.NewScanner(resp.Body)
+
+			if got := readEvents(t, scanner, len(tt.want)); !slices.Equal(got, tt.want) {
+				t.Errorf("events = %v, want %v", got, tt.want)
+			}
+
+			// The stream carries on with live events
+			serve(t, app, http.MethodPost, roomPath+"/guess", api.RoomGuessRequest{Guess: "SLATE"}, v2(guest.Token))
+			if got := readEvents(t, scanner, 1); got[0] != (sseEvent{"4", api.EventGuessMade}) {
+				t.Errorf("live event = %v, want guess_made with ID 4", got[0])
+			}
+		})
+	}
+}
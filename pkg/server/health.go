@@ -0,0 +1,76 @@
+package server
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/admin/wordle/internal/config"
+	"github.com/admin/wordle/internal/game"
+	"github.com/admin/wordle/pkg/api"
+	"github.com/gin-gonic/gin"
+)
+
+// Readiness check names
+const (
+	checkWordList = "word_list"
+	checkStorage  = "storage"
+	checkDraining = "draining"
+)
+
+// HandleHealthz reports that the process is up
+// It stays OK while draining, so a supervisor does not restart a server
+// that is shutting down on purpose.
+func (s *Server) HandleHealthz(c *gin.Context) {
+	c.JSON(http.StatusOK, api.HealthResponse{Status: api.HealthOK})
+}
+
+// HandleReadyz reports whether the server should get traffic, with the
+// result of each check; it answers 503 if any check fails
+func (s *Server) HandleReadyz(c *gin.Context) {
+	checks := []api.HealthCheck{
+		s.checkWordList(),
+		s.checkStorage(),
+		s.checkDraining(),
+	}
+
+	response := api.HealthResponse{Status: api.HealthOK, Checks: checks}
+	status := http.StatusOK
+	for _, check := range checks {
+		if !check.OK {
+			response.Status = api.HealthUnavailable
+			status = http.StatusServiceUnavailable
+		}
+	}
+	c.JSON(status, response)
+}
+
+// checkWordList checks that games can be created from the word list
+func (s *Server) checkWordList() api.HealthCheck {
+	valid := len(game.FilterWords(s.config.WordList, s.config.WordLength))
+	return api.HealthCheck{
+		Name:   checkWordList,
+		OK:     valid > 0,
+		Detail: fmt.Sprintf("%d valid %d-letter words", valid, s.config.WordLength),
+	}
+}
+
+// checkStorage checks that the store can take writes
+func (s *Server) checkStorage() api.HealthCheck {
+	check := api.HealthCheck{Name: checkStorage, OK: true, Detail: s.config.Storage}
+	if s.config.Storage == config.StorageMemory {
+		return check
+	}
+	if err := s.store.Ping(); err != nil {
+		check.OK = false
+		check.Detail = fmt.Sprintf("%s: %v", s.config.Storage, err)
+	}
+	return check
+}
+
+// checkDraining fails once shutdown has begun, so traffic moves elsewhere
+func (s *Server) checkDraining() api.HealthCheck {
+	if s.ShuttingDown() {
+		return api.HealthCheck{Name: checkDraining, OK: false, Detail: ErrShuttingDown.Error()}
+	}
+	return api.HealthCheck{Name: checkDraining, OK: true}
+}
@@ -0,0 +1,93 @@
+package server
+
+import (
+	"net/http"
+	"os"
+	"testing"
+
+	"github.com/admin/wordle/internal/config"
+	"github.com/admin/wordle/pkg/api"
+)
+
+func TestReadyz(t *testing.T) {
+	fileStorage := func(t *testing.T) func(cfg *config.Config) {
+		dir := t.TempDir()
+		return func(cfg *config.Config) {
+			cfg.Storage = config.StorageFile
+			cfg.StoragePath = dir
+		}
+	}
+
+	tests := []struct {
+		name       string
+		configure  func(t *testing.T) func(cfg *config.Config)
+		breakIt    func(t *testing.T, app *App) // Run before the probe, if set
+		wantFailed string                       // The failing check; empty: ready
+	}{
+		{"memory storage", nil, nil, ""},
+		{"file storage", fileStorage, nil, ""},
+		{
+			"no valid word", func(t *testing.T) func(cfg *config.Config) {
+				return func(cfg *config.Config) { cfg.WordList = []string{"CRANES", "CR4NE"} }
+			}, nil, checkWordList,
+		},
+		{
+			"data directory removed", fileStorage, func(t *testing.T, app *App) {
+				if err := os.RemoveAll(app.server.config.StoragePath); err != nil {
+					t.Fatal(err)
+				}
+			}, checkStorage,
+		},
+		{
+			"data directory read-only", fileStorage, func(t *testing.T, app *App) {
+				if os.Geteuid() == 0 {
+					t.Skip("root can write to read-only directories")
+				}
+				dir := app.server.config.StoragePath
+				if err := os.Chmod(dir, 0o555); err != nil {
+					t.Fatal(err)
+				}
+				t.Cleanup(func() { os.Chmod(dir, 0o755) })
+			}, checkStorage,
+		},
+		{"draining", nil, func(t *testing.T, app *App) { app.server.BeginShutdown() }, checkDraining},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var configure func(cfg *config.Config)
+			if tt.configure != nil {
+				configure = tt.configure(t)
+			}
+			app := newTestApp(t, configure)
+			if tt.breakIt != nil {
+				tt.breakIt(t, app)
+			}
+
+			rec := serve(t, app, http.MethodGet, "/readyz", nil, nil)
+			var resp api.HealthResponse
+			decode(t, rec, &resp)
+			wantStatus, wantHealth := http.StatusOK, api.HealthOK
+			if tt.wantFailed != "" {
+				wantStatus, wantHealth = http.StatusServiceUnavailable, api.HealthUnavailable
+			}
+			if rec.Code != wantStatus || resp.Status != wantHealth {
+				t.Errorf("readyz = %d %s, want %d %s", rec.Code, resp.Status, wantStatus, wantHealth)
+			}
+
+			// Every check is listed, whether it passed or not
+			names := []string{checkWordList, checkStorage, checkDraining}
+			if len(resp.Checks) != len(names) {
+				t.Fatalf("checks = %+v, want %v", resp.Checks, names)
+			}
+			for i, check := range resp.Checks {
+				if check.Name != names[i] || check.OK != (check.Name != tt.wantFailed) {
+					t.Errorf("check %d = %+v, want %s passing unless it is %q", i, check, names[i], tt.wantFailed)
+				}
+				if !check.OK && check.Detail == "" {
+					t.Errorf("failed check %s has no detail", check.Name)
+				}
+			}
+		})
+	}
+}
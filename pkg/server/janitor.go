@@ -0,0 +1,181 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"maps"
+	"net/http"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/admin/wordle/internal/config"
+	"github.com/admin/wordle/pkg/api"
+	"github.com/gin-gonic/gin"
+)
+
+// Errors returned for games and rooms removed by the janitor
+var (
+	ErrGameExpired = errors.New("game has expired")
+	ErrRoomExpired = errors.New("room has expired")
+)
+
+// Reasons the janitor removes a game or room
+const (
+	ReasonIdleGame     = "idle_game"
+	ReasonFinishedGame = "finished_game"
+	ReasonIdleRoom     = "idle_room"
+	ReasonFinishedRoom = "finished_room"
+	ReasonEmptyRoom    = "empty_room"
+	ReasonWaitingRoom  = "waiting_room"
+)
+
+// tombstoneTTL is how long expired IDs are remembered, so clients get an
+// "expired" error rather than "not found"
+const tombstoneTTL = time.Hour
+
+// TTLs holds how long games and rooms may stay inactive; negative keeps them forever
+type TTLs struct {
+	IdleGame     time.Duration // Games and rooms in progress
+	FinishedGame time.Duration // Finished games and rooms
+	EmptyRoom    time.Duration // Rooms every player has left
+	WaitingRoom  time.Duration // Rooms that never started
+}
+
+// NewTTLs returns the TTLs set in the configuration
+func NewTTLs(cfg *config.Config) TTLs {
+	return TTLs{
+		IdleGame:     cfg.GameIdleTTL,
+		FinishedGame: cfg.FinishedGameTTL,
+		EmptyRoom:    cfg.EmptyRoomTTL,
+		WaitingRoom:  cfg.WaitingRoomTTL,
+	}
+}
+
+// expired reports whether something last active at lastActive has outlived ttl
+func (t TTLs) expired(ttl time.Duration, lastActive, now time.Time) bool {
+	return ttl >= 0 && now.Sub(lastActive) >= ttl
+}
+
+// janitorStats counts what the janitor has reclaimed
+type janitorStats struct {
+	sweeps    int64
+	lastSweep time.Time
+	reclaimed map[string]int64 // By reason
+	mu        sync.Mutex
+}
+
+// record adds the results of one sweep
+func (j *janitorStats) record(now time.Time, reclaimed map[string]int64) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	j.sweeps++
+	j.lastSweep = now
+	for reason, count := range reclaimed {
+		j.reclaimed[reason] += count
+	}
+}
+
+// RunJanitor removes expired games and rooms every interval until ctx is done
+func (s *Server) RunJanitor(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			s.Sweep(now)
+		}
+	}
+}
+
+// Sweep removes every game and room that has outlived its TTL at now
+// and returns how many were removed, by reason
+func (s *Server) Sweep(now time.Time) map[string]int64 {
+	reclaimed := make(map[string]int64)
+
+	s.mu.RLock()
+	sessions := make([]*GameSession, 0, len(s.sessions))
+	for _, session := range s.sessions {
+		sessions = append(sessions, session)
+	}
+	s.mu.RUnlock()
+
+	for _, session := range sessions {
+		reason, expired := session.expire(s.ttl, now)
+		if !expired {
+			continue
+		}
+		s.mu.Lock()
+		delete(s.sessions, session.ID)
+		s.expired[session.ID] = now
+		s.mu.Unlock()
+		reclaimed[reason]++
+	}
+
+	for _, room := range s.roomManager.Rooms() {
+		reason, expired := room.expire(s.ttl, now)
+		if !expired {
+			continue
+		}
+		s.roomManager.remove(room.ID, now)
+		reclaimed[reason]++
+	}
+
+	// Forget old tombstones
+	cutoff := now.Add(-tombstoneTTL)
+	s.mu.Lock()
+	for id, expiredAt := range s.expired {
+		if expiredAt.Before(cutoff) {
+			delete(s.expired, id)
+		}
+	}
+	s.mu.Unlock()
+	s.roomManager.pruneExpired(cutoff)
+	s.clientLimiter.prune(now)
+	s.tokenLimiter.prune(now)
+
+	s.janitor.record(now, reclaimed)
+	if len(reclaimed) > 0 {
+		attrs := make([]any, 0, len(reclaimed))
+		for _, reason := range slices.Sorted(maps.Keys(reclaimed)) {
+			attrs = append(attrs, slog.Int64(reason, reclaimed[reason]))
+		}
+		slog.Info("janitor reclaimed games and rooms", attrs...)
+	}
+	return reclaimed
+}
+
+// HandleJanitorStats reports what the janitor has reclaimed so far
+func (s *Server) HandleJanitorStats(c *gin.Context) {
+	s.mu.RLock()
+	activeGames := len(s.sessions)
+	s.mu.RUnlock()
+
+	s.janitor.mu.Lock()
+	defer s.janitor.mu.Unlock()
+
+	response := api.JanitorStatsResponse{
+		Sweeps:      s.janitor.sweeps,
+		Reclaimed:   make(map[string]int64, len(s.janitor.reclaimed)),
+		ActiveGames: activeGames,
+		ActiveRooms: len(s.roomManager.Rooms()),
+	}
+	if !s.janitor.lastSweep.IsZero() {
+		response.LastSweep = s.janitor.lastSweep.Unix()
+	}
+	for reason, count := range s.janitor.reclaimed {
+		response.Reclaimed[reason] = count
+		if reason == ReasonIdleGame || reason == ReasonFinishedGame {
+			response.GamesReclaimed += count
+		} else {
+			response.RoomsReclaimed += count
+		}
+	}
+
+	c.JSON(http.StatusOK, response)
+}
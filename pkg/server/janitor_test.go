@@ -0,0 +1,123 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/admin/wordle/pkg/api"
+)
+
+func TestSweep(t *testing.T) {
+	newGame := func(t *testing.T, app *App) string {
+		var created api.NewGameResponse
+		decode(t, serve(t, app, http.MethodPost, "/v1/game/new", nil, nil), &created)
+		return "/v1/game/" + created.GameID
+	}
+	finishedGame := func(t *testing.T, app *App) string {
+		path := newGame(t, app)
+		serve(t, app, http.MethodPost, path+"/guess", api.GuessRequest{Guess: testAnswer}, nil)
+		return path
+	}
+	waitingRoom := func(t *testing.T, app *App) string {
+		return "/v1/room/" + createRoom(t, app).RoomID
+	}
+	emptyRoom := func(t *testing.T, app *App) string {
+		room := createRoom(t, app)
+		serve(t, app, http.MethodPost, "/v1/room/"+room.RoomID+"/leave", nil, v2(room.Token))
+		return "/v1/room/" + room.RoomID
+	}
+	playingRoom := func(t *testing.T, app *App) string {
+		room := createRoom(t, app)
+		joinRoom(t, app, room.RoomID)
+		serve(t, app, http.MethodPost, "/v1/room/"+room.RoomID+"/start", nil, v2(room.Token))
+		return "/v1/room/" + room.RoomID
+	}
+	finishedRoom := func(t *testing.T, app *App) string {
+		room := createRoom(t, app)
+		guest := joinRoom(t, app, room.RoomID)
+		serve(t, app, http.MethodPost, "/v1/room/"+room.RoomID+"/start", nil, v2(room.Token))
+		for _, token := range []string{room.Token, guest.Token} {
+			serve(t, app, http.MethodPost, "/v1/room/"+room.RoomID+"/guess", api.RoomGuessRequest{Guess: testAnswer}, v2(token))
+		}
+		return "/v1/room/" + room.RoomID
+	}
+
+	// Default TTLs: 24h idle, 1h finished or waiting, 5m empty
+	tests := []struct {
+		name       string
+		setup      func(t *testing.T, app *App) string // Returns the game or room path
+		after      time.Duration
+		wantReason string // Empty: kept
+	}{
+		{"game in progress", newGame, 23 * time.Hour, ""},
+		{"idle game", newGame, 24 * time.Hour, ReasonIdleGame},
+		{"finished game", finishedGame, 59 * time.Minute, ""},
+		{"finished game past its TTL", finishedGame, time.Hour, ReasonFinishedGame},
+		{"waiting room", waitingRoom, 59 * time.Minute, ""},
+		{"waiting room past its TTL", waitingRoom, time.Hour, ReasonWaitingRoom},
+		{"empty room", emptyRoom, 4 * time.Minute, ""},
+		{"empty room past its TTL", emptyRoom, 5 * time.Minute, ReasonEmptyRoom},
+		{"room in progress", playingRoom, 23 * time.Hour, ""},
+		{"idle room", playingRoom, 24 * time.Hour, ReasonIdleRoom},
+		{"finished room past its TTL", finishedRoom, time.Hour, ReasonFinishedRoom},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := newTestApp(t, nil)
+			path := tt.setup(t, app) + "/status"
+			now := time.Now().Add(tt.after)
+
+			reclaimed := app.server.Sweep(now)
+			if tt.wantReason == "" {
+				if len(reclaimed) != 0 {
+					t.Errorf("Sweep() = %v, want nothing reclaimed", reclaimed)
+				}
+				if rec := serve(t, app, http.MethodGet, path, nil, nil); rec.Code != http.StatusOK {
+					t.Errorf("GET %s after sweep = %d, want 200", path, rec.Code)
+				}
+				return
+			}
+			if len(reclaimed) != 1 || reclaimed[tt.wantReason] != 1 {
+				t.Errorf("Sweep() = %v, want one %s", reclaimed, tt.wantReason)
+			}
+			wantError(t, serve(t, app, http.MethodGet, path, nil, nil), http.StatusGone, api.CodeExpired)
+
+			var stats api.JanitorStatsResponse
+			decode(t, serve(t, app, http.MethodGet, "/v1/stats/janitor", nil, nil), &stats)
+			if stats.Sweeps != 1 || stats.Reclaimed[tt.wantReason] != 1 || stats.GamesReclaimed+stats.RoomsReclaimed != 1 {
+				t.Errorf("janitor stats = %+v, want one sweep reclaiming one %s", stats, tt.wantReason)
+			}
+
+			// Expired IDs are forgotten after a while
+			app.server.Sweep(now.Add(tombstoneTTL + time.Second))
+			wantError(t, serve(t, app, http.MethodGet, path, nil, nil), http.StatusNotFound, api.CodeNotFound)
+		})
+	}
+}
+
+func TestSweepWakesLongPoll(t *testing.T) {
+	app := newTestApp(t, nil)
+	room := createRoom(t, app)
+	r, _ := app.server.roomManager.GetRoom(room.RoomID)
+	path := "/v1/room/" + room.RoomID + "/progress?version=" + strconv.Itoa(r.GetProgress().Version)
+
+	polled := make(chan *httptest.ResponseRecorder, 1)
+	go func() { polled <- serve(t, app, http.MethodGet, path, nil, nil) }()
+	for waiting := false; !waiting; time.Sleep(10 * time.Millisecond) {
+		r.mu.RLock()
+		waiting = r.listeners > 0
+		r.mu.RUnlock()
+	}
+
+	app.server.Sweep(time.Now().Add(time.Hour))
+	select {
+	case rec := <-polled:
+		wantError(t, rec, http.StatusGone, api.CodeExpired)
+	case <-time.After(5 * time.Second):
+		t.Fatal("long poll still waiting after its room expired")
+	}
+}
@@ -0,0 +1,128 @@
+package server
+
+import (
+	"encoding/hex"
+	"io"
+	"log/slog"
+	"net/http"
+	"runtime/debug"
+	"time"
+
+	"github.com/admin/wordle/internal/config"
+	"github.com/gin-gonic/gin"
+)
+
+// RequestIDHeader carries the request ID, echoed back on every response
+// A client or proxy may send its own to link its logs with the server's.
+const RequestIDHeader = "X-Request-ID"
+
+// Context keys for the request's log state
+const (
+	requestIDKey = "request_id"
+	logAttrsKey  = "log_attrs"
+)
+
+// maxRequestIDLength bounds client-supplied request IDs
+const maxRequestIDLength = 64
+
+// NewLogger returns the server logger for the configured format and level
+// Debug mode always logs at debug level.
+func NewLogger(cfg *config.Config, w io.Writer) *slog.Logger {
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(cfg.LogLevel)); err != nil {
+		level = slog.LevelInfo
+	}
+	if cfg.Mode == config.ModeDebug {
+		level = slog.LevelDebug
+	}
+
+	opts := &slog.HandlerOptions{Level: level}
+	if cfg.LogFormat == config.LogFormatJSON {
+		return slog.New(slog.NewJSONHandler(w, opts))
+	}
+	return slog.New(slog.NewTextHandler(w, opts))
+}
+
+// RequestID gives every request an ID and echoes it in the response
+func RequestID() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id := c.GetHeader(RequestIDHeader)
+		if id == "" || len(id) > maxRequestIDLength {
+			id = hex.EncodeToString(randomBytes(8))
+		}
+		c.Set(requestIDKey, id)
+		c.Header(RequestIDHeader, id)
+		c.Next()
+	}
+}
+
+// AccessLog logs one line per request with its ID, route, status and
+// latency, plus the attributes handlers added with addLogAttrs
+func AccessLog(logger *slog.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		c.Next()
+
+		status := c.Writer.Status()
+		attrs := []slog.Attr{
+			slog.String("request_id", c.GetString(requestIDKey)),
+			slog.String("method", c.Request.Method),
+			slog.String("path", c.Request.URL.Path),
+			slog.String("route", c.FullPath()),
+			slog.Int("status", status),
+			slog.Duration("latency", time.Since(start)),
+			slog.String("client_ip", c.ClientIP()),
+		}
+		if extra, ok := c.Get(logAttrsKey); ok {
+			attrs = append(attrs, extra.([]slog.Attr)...)
+		}
+
+		level := slog.LevelInfo
+		switch {
+		case status >= http.StatusInternalServerError:
+			level = slog.LevelError
+		case isProbe(c) && status == http.StatusOK:
+			level = slog.LevelDebug // Probes run every few seconds
+		}
+		logger.LogAttrs(c.Request.Context(), level, "request", attrs...)
+	}
+}
+
+// isProbe reports whether the request is a liveness or readiness probe
+func isProbe(c *gin.Context) bool {
+	route := c.FullPath()
+	return route == "/healthz" || route == "/readyz"
+}
+
+// Recovery turns a panic into a 500 INTERNAL and logs it with the request ID
+func Recovery(logger *slog.Logger) gin.HandlerFunc {
+	return gin.CustomRecoveryWithWriter(io.Discard, func(c *gin.Context, recovered any) {
+		logger.Error("panic serving request",
+			"request_id", c.GetString(requestIDKey),
+			"path", c.Request.URL.Path,
+			"panic", recovered,
+			"stack", string(debug.Stack()))
+		internalError(c, "Internal server error")
+		c.Abort()
+	})
+}
+
+// addLogAttrs attaches attributes, such as the game or room ID, to the
+// request's access log line
+func addLogAttrs(c *gin.Context, attrs ...slog.Attr) {
+	var all []slog.Attr
+	if existing, ok := c.Get(logAttrsKey); ok {
+		all = existing.([]slog.Attr)
+	}
+	c.Set(logAttrsKey, append(all, attrs...))
+}
+
+// logOutcome records what a request did, and its error if it failed, on
+// the request's access log line
+func logOutcome(c *gin.Context, outcome string, err error) {
+	attrs := []slog.Attr{slog.String("outcome", outcome)}
+	if err != nil {
+		attrs = append(attrs, slog.String("error", err.Error()))
+	}
+	addLogAttrs(c, attrs...)
+}
@@ -0,0 +1,160 @@
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/admin/wordle/pkg/api"
+	"github.com/gin-gonic/gin"
+)
+
+// newLoggedApp returns a test app whose middleware logs JSON to the
+// returned buffer
+func newLoggedApp(t *testing.T) (*App, *bytes.Buffer) {
+	t.Helper()
+	app := newTestApp(t, nil)
+	var logs bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&logs, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+	app.router = gin.New()
+	app.router.Use(RequestID(), AccessLog(logger), Recovery(logger))
+	app.registerRoutes()
+	return app, &logs
+}
+
+// readLogs parses the JSON log lines in logs and empties it
+func readLogs(t *testing.T, logs *bytes.Buffer) []map[string]any {
+	t.Helper()
+	var lines []map[string]any
+	for _, line := range strings.Split(strings.TrimSpace(logs.String()), "\n") {
+		var entry map[string]any
+		if err := json.Unmarshal([]byte(line), &entry); err != nil {
+			t.Fatalf("log line %q is not JSON: %v", line, err)
+		}
+		lines = append(lines, entry)
+	}
+	logs.Reset()
+	return lines
+}
+
+func TestRequestID(t *testing.T) {
+	app, logs := newLoggedApp(t)
+	generated := regexp.MustCompile(`^[0-9a-f]{16}$`)
+
+	tests := []struct {
+		name string
+		sent string
+		want string // Empty: a generated ID
+	}{
+		{"echoed", "trace-42", "trace-42"},
+		{"missing", "", ""},
+		{"longest allowed", strings.Repeat("a", maxRequestIDLength), strings.Repeat("a", maxRequestIDLength)},
+		{"too long", strings.Repeat("a", maxRequestIDLength+1), ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			header := http.Header{}
+			if tt.sent != "" {
+				header.Set(RequestIDHeader, tt.sent)
+			}
+			rec := serve(t, app, http.MethodGet, "/healthz", nil, header)
+			got := rec.Header().Get(RequestIDHeader)
+			if tt.want != "" && got != tt.want || tt.want == "" && !generated.MatchString(got) {
+				t.Errorf("%s = %q, want %q or a generated ID", RequestIDHeader, got, tt.want)
+			}
+			if lines := readLogs(t, logs); len(lines) != 1 || lines[0]["request_id"] != got {
+				t.Errorf("log = %v, want one line with request_id %q", lines, got)
+			}
+		})
+	}
+}
+
+func TestAccessLogAttrs(t *testing.T) {
+	app, logs := newLoggedApp(t)
+	var created api.NewGameResponse
+	decode(t, serve(t, app, http.MethodPost, "/v1/game/new", nil, nil), &created)
+	host := createRoom(t, app)
+	guest := joinRoom(t, app, host.RoomID)
+	serve(t, app, http.MethodPost, "/v1/room/"+host.RoomID+"/start", nil, v2(host.Token))
+	other := createRoom(t, app)
+	logs.Reset()
+
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		body   any
+		header http.Header
+		want   map[string]any
+	}{
+		{
+			"rejected guess", http.MethodPost, "/v1/game/" + created.GameID + "/guess", api.GuessRequest{Guess: "CR4NE"}, nil,
+			map[string]any{"game_id": created.GameID, "outcome": "rejected", "route": "/v1/game/:id/guess", "status": 400.0},
+		},
+		{
+			"winning guess", http.MethodPost, "/v1/game/" + created.GameID + "/guess", api.GuessRequest{Guess: testAnswer}, nil,
+			map[string]any{"game_id": created.GameID, "outcome": "won", "status": 200.0},
+		},
+		{
+			"room guess", http.MethodPost, "/v1/room/" + host.RoomID + "/guess", api.RoomGuessRequest{Guess: testAnswer}, v2(guest.Token),
+			map[string]any{"room_id": host.RoomID, "player_id": guest.PlayerID, "outcome": "won"},
+		},
+		{
+			"join", http.MethodPost, "/v1/room/" + other.RoomID + "/join", api.JoinRoomRequest{Nickname: "late"}, v2(""),
+			map[string]any{"outcome": "joined", "level": "INFO"},
+		},
+		{
+			"unknown game", http.MethodGet, "/v1/game/nogame/status", nil, nil,
+			map[string]any{"game_id": "nogame", "status": 404.0},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			serve(t, app, tt.method, tt.path, tt.body, tt.header)
+			lines := readLogs(t, logs)
+			if len(lines) != 1 {
+				t.Fatalf("log = %v, want one line", lines)
+			}
+			for key, want := range tt.want {
+				if got := lines[0][key]; got != want {
+					t.Errorf("%s = %v, want %v (line %v)", key, got, want, lines[0])
+				}
+			}
+		})
+	}
+}
+
+func TestRecovery(t *testing.T) {
+	app, logs := newLoggedApp(t)
+	app.router.GET("/panic", func(c *gin.Context) { panic("boom") })
+
+	req := httptest.NewRequest(http.MethodGet, "/panic", nil)
+	req.Header.Set(RequestIDHeader, "panic-1")
+	rec := httptest.NewRecorder()
+	app.router.ServeHTTP(rec, req)
+	wantError(t, rec, http.StatusInternalServerError, api.CodeInternal)
+
+	// The panic is logged, then the request, both with its ID
+	lines := readLogs(t, logs)
+	if len(lines) != 2 {
+		t.Fatalf("log = %v, want the panic and the request", lines)
+	}
+	for i, want := range []map[string]any{
+		{"msg": "panic serving request", "level": "ERROR", "panic": "boom", "request_id": "panic-1"},
+		{"msg": "request", "level": "ERROR", "status": 500.0, "request_id": "panic-1"},
+	} {
+		for key, value := range want {
+			if got := lines[i][key]; got != value {
+				t.Errorf("line %d %s = %v, want %v", i+1, key, got, value)
+			}
+		}
+	}
+}
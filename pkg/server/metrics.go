@@ -0,0 +1,232 @@
+package server
+
+import (
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/admin/wordle/internal/game"
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// Game mode labels beyond api.ModeClassic and api.ModeAbsurdle
+const (
+	modeMultiboard = "multiboard"
+	modeDaily      = "daily"
+	modeRoom       = "room" // A multiplayer room's game
+)
+
+// Reasons a guess is rejected, as metric labels
+const (
+	rejectInvalidWord    = "invalid_word"
+	rejectNotInWordList  = "not_in_word_list"
+	rejectHardMode       = "hard_mode"
+	rejectGameOver       = "game_over"
+	rejectNotPlaying     = "not_playing" // Room not started, or player not in it
+	rejectExpired        = "expired"
+	rejectUnknownFailure = "other"
+)
+
+// metrics holds one server's game and request metrics
+// Every method is a no-op on a nil *metrics, so rooms and sessions built
+// without a server, as in tests, need none.
+type metrics struct {
+	registry          *prometheus.Registry
+	gamesCreated      *prometheus.CounterVec
+	gamesFinished     *prometheus.CounterVec
+	guessesAccepted   *prometheus.CounterVec
+	guessesRejected   *prometheus.CounterVec
+	roundsToWin       *prometheus.HistogramVec
+	longPollWaiters   prometheus.Gauge
+	requestsThrottled *prometheus.CounterVec
+	requestDuration   *prometheus.HistogramVec
+}
+
+// Gauges computed from the server's state on every scrape
+var (
+	activeSessionsDesc = prometheus.NewDesc(
+		"wordle_active_sessions",
+		"Single-player game sessions held by the server.",
+		nil, nil)
+	activeRoomsDesc = prometheus.NewDesc(
+		"wordle_active_rooms",
+		"Rooms held by the server, by status.",
+		[]string{"status"}, nil)
+)
+
+// newMetrics returns the game, request and runtime metrics for s, plus
+// gauges for the sessions and rooms it holds, in a registry of their own
+func newMetrics(s *Server) *metrics {
+	m := &metrics{
+		registry: prometheus.NewRegistry(),
+		gamesCreated: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "wordle_games_created_total",
+			Help: "Games created, by mode. A room counts as one game when it starts, however many players it has.",
+		}, []string{"mode"}),
+		gamesFinished: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "wordle_games_finished_total",
+			Help: "Games won or lost, by mode and result. In a room each player's game finishes on its own.",
+		}, []string{"mode", "result"}),
+		guessesAccepted: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "wordle_guesses_total",
+			Help: "Guesses accepted, by mode.",
+		}, []string{"mode"}),
+		guessesRejected: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "wordle_guesses_rejected_total",
+			Help: "Guesses rejected, by mode and reason.",
+		}, []string{"mode", "reason"}),
+		roundsToWin: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "wordle_rounds_to_win",
+			Help:    "Guesses taken to win a game, by mode.",
+			Buckets: prometheus.LinearBuckets(1, 1, 12),
+		}, []string{"mode"}),
+		longPollWaiters: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "wordle_long_poll_waiters",
+			Help: "Room progress long polls currently waiting for an update.",
+		}),
+		requestsThrottled: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "wordle_requests_throttled_total",
+			Help: "Requests answered 429, by rate limit or cap.",
+		}, []string{"reason"}),
+		requestDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name: "wordle_http_request_duration_seconds",
+			Help: "HTTP request latency, by method, route and status code.",
+			// Long polls take up to 30s
+			Buckets: []float64{.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10, 30, 60},
+		}, []string{"method", "route", "status"}),
+	}
+	m.registry.MustRegister(
+		m.gamesCreated,
+		m.gamesFinished,
+		m.guessesAccepted,
+		m.guessesRejected,
+		m.roundsToWin,
+		m.longPollWaiters,
+		m.requestsThrottled,
+		m.requestDuration,
+		serverCollector{s},
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+	)
+	return m
+}
+
+// serverCollector reports the sessions and rooms a server holds
+type serverCollector struct {
+	server *Server
+}
+
+// Describe implements prometheus.Collector
+func (sc serverCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- activeSessionsDesc
+	ch <- activeRoomsDesc
+}
+
+// Collect implements prometheus.Collector
+func (sc serverCollector) Collect(ch chan<- prometheus.Metric) {
+	sc.server.mu.RLock()
+	sessions := len(sc.server.sessions)
+	sc.server.mu.RUnlock()
+	ch <- prometheus.MustNewConstMetric(activeSessionsDesc, prometheus.GaugeValue, float64(sessions))
+
+	rooms := map[RoomStatus]int{RoomWaiting: 0, RoomPlaying: 0, RoomFinished: 0}
+	for _, room := range sc.server.roomManager.Rooms() {
+		room.mu.RLock()
+		rooms[room.Status]++
+		room.mu.RUnlock()
+	}
+	for status, count := range rooms {
+		ch <- prometheus.MustNewConstMetric(activeRoomsDesc, prometheus.GaugeValue, float64(count), string(status))
+	}
+}
+
+// HandleMetrics serves the metrics in Prometheus text format
+func (s *Server) HandleMetrics(c *gin.Context) {
+	promhttp.HandlerFor(s.metrics.registry, promhttp.HandlerOpts{}).ServeHTTP(c.Writer, c.Request)
+}
+
+// MetricsMiddleware records the latency of every request by route
+func (s *Server) MetricsMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		c.Next()
+
+		route := c.FullPath()
+		if route == "" {
+			route = "unmatched" // Keep 404s from creating a series per path
+		}
+		s.metrics.requestDuration.WithLabelValues(
+			c.Request.Method, route, strconv.Itoa(c.Writer.Status()),
+		).Observe(time.Since(start).Seconds())
+	}
+}
+
+// gameCreated counts a new game
+func (m *metrics) gameCreated(mode string) {
+	if m == nil {
+		return
+	}
+	m.gamesCreated.WithLabelValues(mode).Inc()
+}
+
+// guess counts an accepted guess and, if it ended the game, the result
+func (m *metrics) guess(mode string, status game.GameStatus, rounds int) {
+	if m == nil {
+		return
+	}
+	m.guessesAccepted.WithLabelValues(mode).Inc()
+	switch status {
+	case game.Won:
+		m.gamesFinished.WithLabelValues(mode, "won").Inc()
+		m.roundsToWin.WithLabelValues(mode).Observe(float64(rounds))
+	case game.Lost:
+		m.gamesFinished.WithLabelValues(mode, "lost").Inc()
+	}
+}
+
+// rejectedGuess counts a rejected guess
+func (m *metrics) rejectedGuess(mode, reason string) {
+	if m == nil {
+		return
+	}
+	m.guessesRejected.WithLabelValues(mode, reason).Inc()
+}
+
+// throttled counts a request answered 429
+func (m *metrics) throttled(reason string) {
+	if m == nil {
+		return
+	}
+	m.requestsThrottled.WithLabelValues(reason).Inc()
+}
+
+// waiting counts a long poll until the returned func is called
+func (m *metrics) waiting() (done func()) {
+	if m == nil {
+		return func() {}
+	}
+	m.longPollWaiters.Inc()
+	return m.longPollWaiters.Dec
+}
+
+// rejectReason classifies an error from game.MakeGuess
+func rejectReason(err error) string {
+	var hardMode *game.HardModeError
+	switch {
+	case errors.Is(err, game.ErrInvalidWord):
+		return rejectInvalidWord
+	case errors.Is(err, game.ErrNotInWordList):
+		return rejectNotInWordList
+	case errors.As(err, &hardMode):
+		return rejectHardMode
+	case errors.Is(err, game.ErrGameOver):
+		return rejectGameOver
+	case errors.Is(err, ErrGameExpired), errors.Is(err, ErrRoomExpired):
+		return rejectExpired
+	default:
+		return rejectUnknownFailure
+	}
+}
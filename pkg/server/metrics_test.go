@@ -0,0 +1,114 @@
+package server
+
+import (
+	"bufio"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/admin/wordle/pkg/api"
+)
+
+// scrape returns the series app serves on /metrics, keyed by name and labels
+func scrape(t *testing.T, app *App) map[string]float64 {
+	t.Helper()
+	rec := serve(t, app, http.MethodGet, "/metrics", nil, nil)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("GET /metrics = %d, want 200", rec.Code)
+	}
+
+	series := make(map[string]float64)
+	scanner := bufio.NewScanner(rec.Body)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		i := strings.LastIndexByte(line, ' ')
+		value, err := strconv.ParseFloat(line[i+1:], 64)
+		if err != nil {
+			t.Fatalf("metric line %q: %v", line, err)
+		}
+		series[line[:i]] = value
+	}
+	return series
+}
+
+func TestMetrics(t *testing.T) {
+	app := newTestApp(t, nil)
+
+	// A classic game with a rejected guess, won on the next
+	var created api.NewGameResponse
+	decode(t, serve(t, app, http.MethodPost, "/v1/game/new", nil, nil), &created)
+	gamePath := "/v1/game/" + created.GameID + "/guess"
+	serve(t, app, http.MethodPost, gamePath, api.GuessRequest{Guess: "CR4NE"}, nil)
+	serve(t, app, http.MethodPost, gamePath, api.GuessRequest{Guess: testAnswer}, nil)
+
+	// A room of two started once, with a guess before the start; the
+	// guest's win finishes it
+	host := createRoom(t, app)
+	guest := joinRoom(t, app, host.RoomID)
+	roomPath := "/v1/room/" + host.RoomID
+	serve(t, app, http.MethodPost, roomPath+"/guess", api.RoomGuessRequest{Guess: testAnswer}, v2(guest.Token))
+	serve(t, app, http.MethodPost, roomPath+"/start", nil, v2(host.Token))
+	serve(t, app, http.MethodPost, roomPath+"/guess", api.RoomGuessRequest{Guess: testAnswer}, v2(guest.Token))
+
+	// And a room still waiting for its players
+	waiting := createRoom(t, app)
+
+	tests := []struct {
+		series string
+		want   float64
+	}{
+		{`wordle_games_created_total{mode="classic"}`, 1},
+		{`wordle_games_created_total{mode="room"}`, 1},
+		{`wordle_games_finished_total{mode="classic",result="won"}`, 1},
+		{`wordle_games_finished_total{mode="room",result="won"}`, 1},
+		{`wordle_guesses_rejected_total{mode="classic",reason="invalid_word"}`, 1},
+		{`wordle_guesses_rejected_total{mode="room",reason="not_playing"}`, 1},
+		{`wordle_active_sessions`, 1},
+		{`wordle_active_rooms{status="waiting"}`, 1},
+		{`wordle_active_rooms{status="playing"}`, 0},
+		{`wordle_active_rooms{status="finished"}`, 1},
+		{`wordle_long_poll_waiters`, 0},
+		{`wordle_http_request_duration_seconds_count{method="POST",route="/v1/game/:id/guess",status="400"}`, 1},
+		{`wordle_http_request_duration_seconds_count{method="POST",route="/v1/game/:id/guess",status="200"}`, 1},
+		{`wordle_http_request_duration_seconds_count{method="POST",route="/v1/room/create",status="201"}`, 2},
+		{`wordle_http_request_duration_seconds_count{method="POST",route="/v1/room/:id/guess",status="200"}`, 1},
+	}
+
+	series := scrape(t, app)
+	for _, tt := range tests {
+		if got, ok := series[tt.series]; !ok || got != tt.want {
+			t.Errorf("%s = %v (present: %v), want %v", tt.series, got, ok, tt.want)
+		}
+	}
+
+	// A long poll counts as a waiter until it ends
+	r, _ := app.server.roomManager.GetRoom(waiting.RoomID)
+	path := "/v1/room/" + waiting.RoomID + "/progress?version=" + strconv.Itoa(r.GetProgress().Version)
+	polled := make(chan struct{})
+	go func() {
+		serve(t, app, http.MethodGet, path, nil, nil)
+		close(polled)
+	}()
+	deadline := time.Now().Add(5 * time.Second)
+	for scrape(t, app)["wordle_long_poll_waiters"] != 1 {
+		if time.Now().After(deadline) {
+			t.Fatal("wordle_long_poll_waiters never reached 1 during a long poll")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	app.server.ReleaseWaiters()
+	<-polled
+	if got := scrape(t, app)["wordle_long_poll_waiters"]; got != 0 {
+		t.Errorf("wordle_long_poll_waiters = %v after the poll ended, want 0", got)
+	}
+
+	// Another app keeps its own count
+	if got, ok := scrape(t, newTestApp(t, nil))[`wordle_games_created_total{mode="classic"}`]; ok {
+		t.Errorf("a new app reports %v classic games, want none", got)
+	}
+}
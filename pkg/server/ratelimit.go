@@ -0,0 +1,215 @@
+package server
+
+import (
+	"errors"
+	"log/slog"
+	"math"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Errors returned for throttled requests and requests over a cap
+var (
+	ErrRateLimited      = errors.New("too many requests")
+	ErrTooManyGames     = errors.New("too many unfinished games for this client")
+	ErrTooManyRooms     = errors.New("server has too many rooms")
+	ErrTooManyListeners = errors.New("too many clients waiting on this room")
+)
+
+// gameCapWindow is how recently an unfinished game must have been played to
+// count toward max_games_per_client, so abandoned games stop holding slots
+// long before the janitor reclaims them
+const gameCapWindow = 10 * time.Minute
+
+// Retry-After hints for caps, which free up when rooms are reclaimed or
+// listeners leave rather than at a known time
+const (
+	roomCapRetry     = time.Minute
+	listenerCapRetry = 5 * time.Second
+)
+
+// Throttle reasons, as metric labels
+const (
+	throttleClient    = "client"
+	throttleToken     = "token"
+	throttleGames     = "games"
+	throttleRooms     = "rooms"
+	throttleListeners = "listeners"
+)
+
+// retryError asks the client to retry after a delay
+// writeError sends the delay as a Retry-After header.
+type retryError struct {
+	err   error
+	after time.Duration
+}
+
+// Error implements the error interface
+func (e *retryError) Error() string {
+	return e.err.Error()
+}
+
+// Unwrap returns the underlying error
+func (e *retryError) Unwrap() error {
+	return e.err
+}
+
+// retryAfter wraps err with a delay before the client should retry
+func retryAfter(err error, after time.Duration) error {
+	return &retryError{err: err, after: after}
+}
+
+// retrySeconds returns a Retry-After value: whole seconds, at least 1
+func retrySeconds(after time.Duration) string {
+	return strconv.Itoa(max(1, int(math.Ceil(after.Seconds()))))
+}
+
+// bucket is one key's token bucket
+type bucket struct {
+	tokens float64
+	last   time.Time // When tokens was last brought up to date
+}
+
+// rateLimiter keeps a token bucket per key, e.g. per client IP
+// Each bucket refills at rate tokens per second up to burst; a request
+// takes one token.
+type rateLimiter struct {
+	rate    float64
+	burst   float64
+	buckets map[string]*bucket
+	mu      sync.Mutex
+}
+
+// newRateLimiter returns a limiter, or nil (no limit) if rate or burst is
+// not positive
+func newRateLimiter(rate float64, burst int) *rateLimiter {
+	if rate <= 0 || burst <= 0 {
+		return nil
+	}
+	return &rateLimiter{
+		rate:    rate,
+		burst:   float64(burst),
+		buckets: make(map[string]*bucket),
+	}
+}
+
+// allow takes a token from key's bucket at now
+// If the bucket is empty it returns false and how long until it has a token.
+func (l *rateLimiter) allow(key string, now time.Time) (bool, time.Duration) {
+	if l == nil {
+		return true, 0
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	b, ok := l.buckets[key]
+	if !ok {
+		b = &bucket{tokens: l.burst, last: now}
+		l.buckets[key] = b
+	}
+	b.tokens = min(l.burst, b.tokens+now.Sub(b.last).Seconds()*l.rate)
+	b.last = now
+
+	if b.tokens < 1 {
+		wait := time.Duration((1 - b.tokens) / l.rate * float64(time.Second))
+		return false, wait
+	}
+	b.tokens--
+	return true, 0
+}
+
+// prune forgets buckets that have refilled by now, since a full bucket is
+// the same as none
+func (l *rateLimiter) prune(now time.Time) {
+	if l == nil {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	for key, b := range l.buckets {
+		if b.tokens+now.Sub(b.last).Seconds()*l.rate >= l.burst {
+			delete(l.buckets, key)
+		}
+	}
+}
+
+// RateLimit throttles requests per client IP and, when a player token is
+// sent, per token, answering 429 with Retry-After
+func (s *Server) RateLimit() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		now := time.Now()
+		if ok, wait := s.clientLimiter.allow(c.ClientIP(), now); !ok {
+			s.throttle(c, throttleClient, retryAfter(ErrRateLimited, wait))
+			return
+		}
+
+		token := bearerToken(c)
+		if token == "" {
+			token = c.Query("token") // WebSockets from browsers
+		}
+		if token != "" {
+			if ok, wait := s.tokenLimiter.allow(hashToken(token), now); !ok {
+				s.throttle(c, throttleToken, retryAfter(ErrRateLimited, wait))
+				return
+			}
+		}
+		c.Next()
+	}
+}
+
+// throttle rejects a request with 429 and counts it under reason
+func (s *Server) throttle(c *gin.Context, reason string, err error) {
+	s.metrics.throttled(reason)
+	logOutcome(c, "throttled", err)
+	addLogAttrs(c, slog.String("throttle", reason))
+	writeError(c, err)
+	c.Abort()
+}
+
+// addRoomListener counts a client waiting on room, writing a 429 if the
+// room already has max_room_listeners; call room.removeListener when done
+func (s *Server) addRoomListener(c *gin.Context, room *Room) bool {
+	if room.addListener(s.config.MaxRoomListeners) {
+		return true
+	}
+	s.throttle(c, throttleListeners, retryAfter(ErrTooManyListeners, listenerCapRetry))
+	return false
+}
+
+// withinGameLimit reports whether client may create another game, writing
+// a 429 if not (must be called with s.mu held)
+// Only unfinished games played within gameCapWindow count; Retry-After is
+// when the first of them drops out of the window.
+func (s *Server) withinGameLimit(c *gin.Context, client string) bool {
+	limit := s.config.MaxGamesPerClient
+	if limit <= 0 {
+		return true
+	}
+
+	now := time.Now()
+	active := 0
+	var freed time.Duration // Until an active game stops counting
+	for _, session := range s.sessions {
+		if session.client != client {
+			continue
+		}
+		session.mu.RLock()
+		idle := now.Sub(session.lastActive)
+		if !session.isOver() && idle < gameCapWindow {
+			active++
+			if left := gameCapWindow - idle; active == 1 || left < freed {
+				freed = left
+			}
+		}
+		session.mu.RUnlock()
+	}
+	if active < limit {
+		return true
+	}
+	s.throttle(c, throttleGames, retryAfter(ErrTooManyGames, freed))
+	return false
+}
@@ -0,0 +1,249 @@
+package server
+
+import (
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/admin/wordle/internal/config"
+	"github.com/admin/wordle/pkg/api"
+)
+
+func TestRateLimiterAllow(t *testing.T) {
+	start := time.Unix(1_700_000_000, 0)
+
+	// A bucket of 2 refilling at 4 tokens per second
+	tests := []struct {
+		name     string
+		requests []time.Duration // Offsets from start
+		wantOK   bool            // For the last request
+		wantWait time.Duration
+	}{
+		{"first request", []time.Duration{0}, true, 0},
+		{"within the burst", []time.Duration{0, 0}, true, 0},
+		{"over the burst", []time.Duration{0, 0, 0}, false, 250 * time.Millisecond},
+		{"partly refilled", []time.Duration{0, 0, 100 * time.Millisecond}, false, 150 * time.Millisecond},
+		{"refilled", []time.Duration{0, 0, 250 * time.Millisecond}, true, 0},
+		{"refill stops at the burst", []time.Duration{0, 0, time.Hour, time.Hour, time.Hour}, false, 250 * time.Millisecond},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := newRateLimiter(4, 2)
+			var ok bool
+			var wait time.Duration
+			for _, offset := range tt.requests {
+				ok, wait = l.allow("client", start.Add(offset))
+			}
+			if ok != tt.wantOK || wait != tt.wantWait {
+				t.Errorf("allow() = %v, %v; want %v, %v", ok, wait, tt.wantOK, tt.wantWait)
+			}
+			// Other keys have their own bucket
+			if ok, _ := l.allow("other", start); !ok {
+				t.Error("allow() for another key = false, want true")
+			}
+		})
+	}
+}
+
+func TestRateLimiterPrune(t *testing.T) {
+	start := time.Unix(1_700_000_000, 0)
+	l := newRateLimiter(4, 2)
+	l.allow("idle", start)
+	l.allow("busy", start)
+	l.allow("busy", start.Add(time.Second))
+	l.allow("busy", start.Add(time.Second))
+
+	// By start+1.1s idle has refilled, busy has not
+	l.prune(start.Add(1100 * time.Millisecond))
+	if _, ok := l.buckets["idle"]; ok {
+		t.Error("prune() kept a full bucket")
+	}
+	if _, ok := l.buckets["busy"]; !ok {
+		t.Error("prune() dropped a bucket that has not refilled")
+	}
+
+	// No limit
+	var none *rateLimiter
+	if ok, _ := none.allow("client", start); !ok {
+		t.Error("nil limiter should allow every request")
+	}
+	none.prune(start)
+}
+
+func TestRateLimit(t *testing.T) {
+	const proxy = "192.0.2.1" // httptest.NewRequest's RemoteAddr
+
+	tests := []struct {
+		name      string
+		configure func(cfg *config.Config)
+		second    http.Header // Headers of the second request; the first sends none
+		wantCode  string      // Empty: the second request is allowed
+	}{
+		{"same client", perClient(nil), nil, api.CodeRateLimited},
+		{"forwarded by an untrusted proxy", perClient(nil), forwardedFor("198.51.100.7"), api.CodeRateLimited},
+		{"forwarded by a trusted proxy", perClient([]string{proxy}), forwardedFor("198.51.100.7"), ""},
+		{"trusted proxy by CIDR", perClient([]string{"192.0.2.0/24"}), forwardedFor("198.51.100.7"), ""},
+		{"same token", perToken, bearer("secret"), api.CodeRateLimited},
+		{"another token", perToken, bearer("other"), ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := newTestApp(t, tt.configure)
+			serve(t, app, http.MethodGet, "/v1/room/list", nil, bearer("secret"))
+			rec := serve(t, app, http.MethodGet, "/v1/room/list", nil, tt.second)
+			if tt.wantCode == "" {
+				if rec.Code != http.StatusOK {
+					t.Errorf("second request = %d %s, want 200", rec.Code, rec.Body)
+				}
+				return
+			}
+			wantError(t, rec, http.StatusTooManyRequests, tt.wantCode)
+			if rec.Header().Get("Retry-After") != "1" {
+				t.Errorf("Retry-After = %q, want 1", rec.Header().Get("Retry-After"))
+			}
+		})
+	}
+
+	// Maintenance routes are never limited
+	app := newTestApp(t, perClient(nil))
+	for range 3 {
+		if rec := serve(t, app, http.MethodGet, "/healthz", nil, nil); rec.Code != http.StatusOK {
+			t.Fatalf("healthz = %d, want 200", rec.Code)
+		}
+	}
+}
+
+// perClient allows one request per client IP, trusting proxies
+func perClient(proxies []string) func(cfg *config.Config) {
+	return func(cfg *config.Config) {
+		cfg.RateLimit = 1
+		cfg.RateBurst = 1
+		cfg.TrustedProxies = proxies
+	}
+}
+
+// perToken allows one request per player token
+func perToken(cfg *config.Config) {
+	cfg.TokenRateLimit = 1
+	cfg.TokenRateBurst = 1
+}
+
+// forwardedFor returns an X-Forwarded-For header naming ip
+func forwardedFor(ip string) http.Header {
+	return http.Header{"X-Forwarded-For": {ip}}
+}
+
+func TestGameCap(t *testing.T) {
+	tests := []struct {
+		name string
+		// change is applied to the first of the client's two games
+		change    func(t *testing.T, app *App, id string)
+		wantRetry string // Empty: a third game is allowed
+	}{
+		{"two active games", nil, "600"},
+		{"one game finished", func(t *testing.T, app *App, id string) {
+			serve(t, app, http.MethodPost, "/v1/game/"+id+"/guess", api.GuessRequest{Guess: testAnswer}, nil)
+		}, ""},
+		{"one game idle past the window", func(t *testing.T, app *App, id string) {
+			setLastActive(app, id, time.Now().Add(-gameCapWindow))
+		}, ""},
+		{"one game idle for most of the window", func(t *testing.T, app *App, id string) {
+			setLastActive(app, id, time.Now().Add(-gameCapWindow+90*time.Second))
+		}, "90"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := newTestApp(t, func(cfg *config.Config) { cfg.MaxGamesPerClient = 2 })
+			var first api.NewGameResponse
+			decode(t, serve(t, app, http.MethodPost, "/v1/game/new", nil, nil), &first)
+			serve(t, app, http.MethodPost, "/v1/game/new", nil, nil)
+			if tt.change != nil {
+				tt.change(t, app, first.GameID)
+			}
+
+			rec := serve(t, app, http.MethodPost, "/v1/game/new", nil, nil)
+			if tt.wantRetry == "" {
+				if rec.Code != http.StatusCreated {
+					t.Errorf("third game = %d %s, want 201", rec.Code, rec.Body)
+				}
+				return
+			}
+			wantError(t, rec, http.StatusTooManyRequests, api.CodeTooManyGames)
+			if got := rec.Header().Get("Retry-After"); got != tt.wantRetry {
+				t.Errorf("Retry-After = %q, want %q", got, tt.wantRetry)
+			}
+		})
+	}
+}
+
+func TestGameCapConcurrent(t *testing.T) {
+	const limit, requests = 3, 50
+	app := newTestApp(t, func(cfg *config.Config) { cfg.MaxGamesPerClient = limit })
+
+	codes := make(chan int, requests)
+	var wg sync.WaitGroup
+	for range requests {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			codes <- serve(t, app, http.MethodPost, "/v1/game/new", nil, nil).Code
+		}()
+	}
+	wg.Wait()
+	close(codes)
+
+	created := 0
+	for code := range codes {
+		if code == http.StatusCreated {
+			created++
+		} else if code != http.StatusTooManyRequests {
+			t.Errorf("status = %d, want 201 or 429", code)
+		}
+	}
+	if created != limit {
+		t.Errorf("%d games created at once, want the cap of %d", created, limit)
+	}
+}
+
+// setLastActive backdates the last guess of game id
+func setLastActive(app *App, id string, at time.Time) {
+	app.server.mu.RLock()
+	session := app.server.sessions[id]
+	app.server.mu.RUnlock()
+	session.mu.Lock()
+	session.lastActive = at
+	session.mu.Unlock()
+}
+
+func TestRoomCaps(t *testing.T) {
+	app := newTestApp(t, func(cfg *config.Config) {
+		cfg.MaxRooms = 1
+		cfg.MaxRoomListeners = 1
+	})
+	var room api.CreateRoomResponse
+	decode(t, serve(t, app, http.MethodPost, "/v1/room/create", api.CreateRoomRequest{Nickname: "host"}, v2("")), &room)
+
+	rec := serve(t, app, http.MethodPost, "/v1/room/create", api.CreateRoomRequest{Nickname: "other"}, v2(""))
+	wantError(t, rec, http.StatusTooManyRequests, api.CodeTooManyRooms)
+	if got := rec.Header().Get("Retry-After"); got != "60" {
+		t.Errorf("room cap Retry-After = %q, want 60", got)
+	}
+
+	// One client is already waiting on the room
+	r, _ := app.server.roomManager.GetRoom(room.RoomID)
+	if !r.addListener(1) {
+		t.Fatal("addListener() = false on an empty room")
+	}
+	defer r.removeListener()
+	for _, path := range []string{"/progress?version=0", "/events", "/ws"} {
+		rec := serve(t, app, http.MethodGet, "/v1/room/"+room.RoomID+path, nil, nil)
+		wantError(t, rec, http.StatusTooManyRequests, api.CodeTooManyListeners)
+		if got := rec.Header().Get("Retry-After"); got != "5" {
+			t.Errorf("%s Retry-After = %q, want 5", path, got)
+		}
+	}
+}
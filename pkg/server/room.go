@@ -1,7 +1,13 @@
 package server
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"log/slog"
+	"slices"
+	"sort"
+	"strings"
 	"sync"
 	"time"
 
@@ -28,6 +34,19 @@ const (
 	PlayerLost    PlayerStatus = "lost"
 )
 
+// Errors returned for rejected room actions
+var (
+	ErrRoomFull         = errors.New("room is full")
+	ErrRoomClosed       = errors.New("room is not accepting new players")
+	ErrAlreadyInRoom    = errors.New("player already in room")
+	ErrNotInRoom        = errors.New("player not in room")
+	ErrNotHost          = errors.New("only host can start the game")
+	ErrAlreadyStarted   = errors.New("game already started")
+	ErrNotEnoughPlayers = errors.New("need at least 2 players to start")
+	ErrRoomNotPlaying   = errors.New("game not in progress")
+	ErrPlayerFinished   = errors.New("player already finished")
+)
+
 // Player represents a player in a room
 type Player struct {
 	ID         string
@@ -35,90 +54,259 @@ type Player struct {
 	Status     PlayerStatus
 	Game       *game.Game
 	History    []api.GuessResponse
-	FinishTime int64 // Unix timestamp when won or lost
+	FinishTime int64  // Unix timestamp when won or lost
+	tokenHash  string // Hash of the player's secret token
 	mu         sync.RWMutex
 }
 
 // Room represents a multiplayer game room
 type Room struct {
-	ID          string
-	Host        string // Player ID of the host
-	Answer      string
-	MaxRounds   int
-	MaxPlayers  int
-	Status      RoomStatus
-	Players     map[string]*Player // key: playerID
-	PlayerOrder []string           // Maintain join order
-	Version     int                // For long polling
-	updateCond  *sync.Cond         // Condition variable for broadcasting updates
-	mu          sync.RWMutex
+	ID            string
+	Host          string // Player ID of the host
+	Answer        string
+	WordList      []string // Answer pool, for remaining candidates
+	MaxRounds     int
+	WordLength    int
+	HardMode      bool               // Revealed hints must be used in subsequent guesses
+	Dictionary    game.Dictionary    // Allowed guesses; nil accepts any valid word
+	Normalization game.Normalization // Language rules applied to guesses
+	MaxPlayers    int
+	Status        RoomStatus
+	Players       map[string]*Player // key: playerID
+	PlayerOrder   []string           // Maintain join order
+	Version       int                // For long polling
+	updated       chan struct{}      // Closed and replaced on every update, waking waiters
+	events        []api.RoomEvent    // Recent changes, oldest first, for event streams
+	lastActive    time.Time          // Time of the last update, for expiry
+	expired       bool               // Removed by the janitor; waiters must give up
+	listeners     int                // Long polls, event streams and WebSockets waiting on the room
+	store         Store              // Write-through persistence; nil disables it
+	metrics       *metrics           // nil counts nothing
+	mu            sync.RWMutex
+}
+
+// maxRoomEvents is how many recent events a room keeps
+// Clients that fall further behind get a full progress snapshot instead.
+const maxRoomEvents = 256
+
+// RoomSettings holds the game settings shared by all players in a room
+type RoomSettings struct {
+	MaxPlayers    int
+	MaxRounds     int
+	WordLength    int
+	HardMode      bool
+	WordList      []string           // Answer pool
+	Dictionary    game.Dictionary    // Allowed guesses; nil accepts any valid word
+	Normalization game.Normalization // Language rules applied to guesses
 }
 
 // RoomManager manages all game rooms
 type RoomManager struct {
-	rooms     map[string]*Room
-	idCounter int
-	mu        sync.RWMutex
+	rooms    map[string]*Room
+	expired  map[string]time.Time // Recently expired room IDs, for "expired" errors
+	store    Store
+	metrics  *metrics // Passed to every room; nil counts nothing
+	maxRooms int      // 0 or less is unlimited
+	mu       sync.RWMutex
 }
 
-// NewRoomManager creates a new room manager
-func NewRoomManager() *RoomManager {
+// NewRoomManager creates a new room manager that saves rooms to store
+func NewRoomManager(store Store) *RoomManager {
 	return &RoomManager{
-		rooms: make(map[string]*Room),
+		rooms:   make(map[string]*Room),
+		expired: make(map[string]time.Time),
+		store:   store,
 	}
 }
 
-// CreateRoom creates a new game room
-func (rm *RoomManager) CreateRoom(playerID, nickname string, maxPlayers, maxRounds int, wordList []string) (*Room, error) {
-	// Select a random word for the room
-	answer := wordList[game.GetRandomInt(len(wordList))]
+// SetMaxRooms caps how many rooms the manager holds; 0 or less is unlimited
+func (rm *RoomManager) SetMaxRooms(n int) {
+	rm.mu.Lock()
+	defer rm.mu.Unlock()
+	rm.maxRooms = n
+}
 
+// SetMetrics sets the metrics the manager's rooms count games and guesses in
+func (rm *RoomManager) SetMetrics(m *metrics) {
 	rm.mu.Lock()
 	defer rm.mu.Unlock()
+	rm.metrics = m
+}
 
-	rm.idCounter++
-	roomID := fmt.Sprintf("%d", rm.idCounter)
+// CreateRoom creates a new game room with a random ID
+// tokenHash is the hash of the host's secret token.
+func (rm *RoomManager) CreateRoom(playerID, nickname, tokenHash string, settings RoomSettings) (*Room, error) {
+	// Select a random word of the configured length for the room
+	validWords := game.FilterWords(settings.WordList, settings.WordLength)
+	if len(validWords) == 0 {
+		return nil, fmt.Errorf("no valid %d-letter words in word list", settings.WordLength)
+	}
+	answer := validWords[game.GetRandomInt(len(validWords))]
 
+	rm.mu.Lock()
+	defer rm.mu.Unlock()
+
+	if rm.maxRooms > 0 && len(rm.rooms) >= rm.maxRooms {
+		return nil, ErrTooManyRooms
+	}
+
+	roomID := newRoomID()
+	for rm.taken(roomID) {
+		roomID = newRoomID()
+	}
+
+	maxPlayers := settings.MaxPlayers
 	if maxPlayers <= 0 || maxPlayers > 8 {
 		maxPlayers = 4
 	}
 
 	room := &Room{
-		ID:          roomID,
-		Host:        playerID,
-		Answer:      answer,
-		MaxRounds:   maxRounds,
-		MaxPlayers:  maxPlayers,
-		Status:      RoomWaiting,
-		Players:     make(map[string]*Player),
-		PlayerOrder: make([]string, 0),
-		Version:     0,
-	}
-	// Initialize condition variable for broadcasting updates
-	room.updateCond = sync.NewCond(&room.mu)
+		ID:            roomID,
+		Host:          playerID,
+		Answer:        answer,
+		WordList:      validWords,
+		MaxRounds:     settings.MaxRounds,
+		WordLength:    settings.WordLength,
+		HardMode:      settings.HardMode,
+		Dictionary:    settings.Dictionary,
+		Normalization: settings.Normalization,
+		MaxPlayers:    maxPlayers,
+		Status:        RoomWaiting,
+		Players:       make(map[string]*Player),
+		PlayerOrder:   make([]string, 0),
+		Version:       0,
+		lastActive:    time.Now(),
+		store:         rm.store,
+		metrics:       rm.metrics,
+	}
+	room.updated = make(chan struct{})
 
 	// Add host as first player
 	player := &Player{
-		ID:       playerID,
-		Nickname: nickname,
-		Status:   PlayerWaiting,
-		History:  make([]api.GuessResponse, 0),
+		ID:        playerID,
+		Nickname:  nickname,
+		Status:    PlayerWaiting,
+		History:   make([]api.GuessResponse, 0),
+		tokenHash: tokenHash,
 	}
 	room.Players[playerID] = player
 	room.PlayerOrder = append(room.PlayerOrder, playerID)
 
+	room.persist()
 	rm.rooms[roomID] = room
 	return room, nil
 }
 
-// GetRoom gets a room by ID
+// taken reports whether a room ID is in use or recently expired (must be called with lock held)
+func (rm *RoomManager) taken(roomID string) bool {
+	_, exists := rm.rooms[roomID]
+	_, expired := rm.expired[roomID]
+	return exists || expired
+}
+
+// RestoreRoom registers a room loaded from the store
+// The answer pool, dictionary and language rules come from settings.
+func (rm *RoomManager) RestoreRoom(record *RoomRecord, settings RoomSettings) *Room {
+	room := &Room{
+		ID:            record.ID,
+		Host:          record.Host,
+		Answer:        record.Answer,
+		WordList:      game.FilterWords(settings.WordList, record.WordLength),
+		MaxRounds:     record.MaxRounds,
+		WordLength:    record.WordLength,
+		HardMode:      record.HardMode,
+		Dictionary:    settings.Dictionary,
+		Normalization: settings.Normalization,
+		MaxPlayers:    record.MaxPlayers,
+		Status:        record.Status,
+		Players:       make(map[string]*Player),
+		PlayerOrder:   make([]string, 0, len(record.Players)),
+		Version:       record.Version,
+		events:        record.Events,
+		lastActive:    record.LastActive,
+		store:         rm.store,
+		metrics:       rm.metrics,
+	}
+	room.updated = make(chan struct{})
+	if room.lastActive.IsZero() {
+		room.lastActive = time.Now()
+	}
+
+	for _, saved := range record.Players {
+		player := &Player{
+			ID:         saved.ID,
+			Nickname:   saved.Nickname,
+			Status:     saved.Status,
+			Game:       saved.Game,
+			History:    saved.History,
+			FinishTime: saved.FinishTime,
+			tokenHash:  saved.TokenHash,
+		}
+		if player.History == nil {
+			player.History = make([]api.GuessResponse, 0)
+		}
+		if player.Game != nil {
+			player.Game.WordList = room.WordList
+			player.Game.Dictionary = room.Dictionary
+		}
+		room.Players[player.ID] = player
+		room.PlayerOrder = append(room.PlayerOrder, player.ID)
+	}
+
+	rm.mu.Lock()
+	defer rm.mu.Unlock()
+
+	rm.rooms[room.ID] = room
+	return room
+}
+
+// GetRoom gets a room by ID, ignoring case
 func (rm *RoomManager) GetRoom(roomID string) (*Room, bool) {
 	rm.mu.RLock()
 	defer rm.mu.RUnlock()
-	room, exists := rm.rooms[roomID]
+	room, exists := rm.rooms[strings.ToUpper(roomID)]
 	return room, exists
 }
 
+// IsExpired reports whether a room was recently removed by the janitor
+func (rm *RoomManager) IsExpired(roomID string) bool {
+	rm.mu.RLock()
+	defer rm.mu.RUnlock()
+	_, expired := rm.expired[strings.ToUpper(roomID)]
+	return expired
+}
+
+// Rooms returns every room
+func (rm *RoomManager) Rooms() []*Room {
+	rm.mu.RLock()
+	defer rm.mu.RUnlock()
+
+	rooms := make([]*Room, 0, len(rm.rooms))
+	for _, room := range rm.rooms {
+		rooms = append(rooms, room)
+	}
+	return rooms
+}
+
+// remove drops an expired room, remembering its ID until the tombstone is pruned
+func (rm *RoomManager) remove(roomID string, now time.Time) {
+	rm.mu.Lock()
+	defer rm.mu.Unlock()
+	delete(rm.rooms, roomID)
+	rm.expired[roomID] = now
+}
+
+// pruneExpired forgets rooms that expired before cutoff
+func (rm *RoomManager) pruneExpired(cutoff time.Time) {
+	rm.mu.Lock()
+	defer rm.mu.Unlock()
+	for id, expiredAt := range rm.expired {
+		if expiredAt.Before(cutoff) {
+			delete(rm.expired, id)
+		}
+	}
+}
+
 // ListRooms lists all available rooms (waiting status)
 func (rm *RoomManager) ListRooms() []*Room {
 	rm.mu.RLock()
@@ -134,42 +322,92 @@ func (rm *RoomManager) ListRooms() []*Room {
 }
 
 // JoinRoom adds a player to a room
-func (r *Room) JoinRoom(playerID, nickname string) error {
+// tokenHash is the hash of the player's secret token.
+func (r *Room) JoinRoom(playerID, nickname, tokenHash string) error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
+	if r.expired {
+		return ErrRoomExpired
+	}
 	if r.Status != RoomWaiting {
-		return fmt.Errorf("room is not accepting new players")
+		return ErrRoomClosed
 	}
 
 	if len(r.Players) >= r.MaxPlayers {
-		return fmt.Errorf("room is full")
+		return ErrRoomFull
 	}
 
 	if _, exists := r.Players[playerID]; exists {
-		return fmt.Errorf("player already in room")
+		return ErrAlreadyInRoom
 	}
 
 	player := &Player{
-		ID:       playerID,
-		Nickname: nickname,
-		Status:   PlayerWaiting,
-		History:  make([]api.GuessResponse, 0),
+		ID:        playerID,
+		Nickname:  nickname,
+		Status:    PlayerWaiting,
+		History:   make([]api.GuessResponse, 0),
+		tokenHash: tokenHash,
 	}
 	r.Players[playerID] = player
 	r.PlayerOrder = append(r.PlayerOrder, playerID)
 
-	r.notifyUpdate()
+	r.notifyUpdate(api.RoomEvent{
+		Type:     api.EventPlayerJoined,
+		PlayerID: playerID,
+		Nickname: nickname,
+	})
 	return nil
 }
 
+// Authenticate returns the ID of the player holding token
+func (r *Room) Authenticate(token string) (string, error) {
+	if token == "" {
+		return "", ErrMissingToken
+	}
+	hash := hashToken(token)
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, player := range r.Players {
+		if tokenMatches(hash, player.tokenHash) {
+			return player.ID, nil
+		}
+	}
+	return "", ErrInvalidToken
+}
+
+// AuthenticateLegacy returns playerID if it is a player that joined with
+// protocol 1 and so has no token; players with a token must send it
+func (r *Room) AuthenticateLegacy(playerID string) (string, error) {
+	if playerID == "" {
+		return "", ErrMissingToken
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	player, exists := r.Players[playerID]
+	if !exists {
+		return "", ErrNotInRoom
+	}
+	if player.tokenHash != "" {
+		return "", ErrMissingToken
+	}
+	return playerID, nil
+}
+
 // LeaveRoom removes a player from a room
 func (r *Room) LeaveRoom(playerID string) error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
+	if r.expired {
+		return ErrRoomExpired
+	}
 	if _, exists := r.Players[playerID]; !exists {
-		return fmt.Errorf("player not in room")
+		return ErrNotInRoom
 	}
 
 	delete(r.Players, playerID)
@@ -189,7 +427,11 @@ func (r *Room) LeaveRoom(playerID string) error {
 		}
 	}
 
-	r.notifyUpdate()
+	r.notifyUpdate(api.RoomEvent{
+		Type:     api.EventPlayerLeft,
+		PlayerID: playerID,
+		Host:     r.Host,
+	})
 	return nil
 }
 
@@ -198,16 +440,19 @@ func (r *Room) StartGame(playerID string) error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
+	if r.expired {
+		return ErrRoomExpired
+	}
 	if playerID != r.Host {
-		return fmt.Errorf("only host can start the game")
+		return ErrNotHost
 	}
 
 	if r.Status != RoomWaiting {
-		return fmt.Errorf("game already started")
+		return ErrAlreadyStarted
 	}
 
 	if len(r.Players) < 2 {
-		return fmt.Errorf("need at least 2 players to start")
+		return ErrNotEnoughPlayers
 	}
 
 	// Initialize game for each player
@@ -216,12 +461,17 @@ func (r *Room) StartGame(playerID string) error {
 		if err != nil {
 			return err
 		}
+		g.HardMode = r.HardMode
+		g.Dictionary = r.Dictionary
+		g.Normalization = r.Normalization
+		g.WordList = r.WordList
 		player.Game = g
 		player.Status = PlayerPlaying
 	}
+	r.metrics.gameCreated(modeRoom)
 
 	r.Status = RoomPlaying
-	r.notifyUpdate()
+	r.notifyUpdate(api.RoomEvent{Type: api.EventGameStarted})
 	return nil
 }
 
@@ -230,24 +480,33 @@ func (r *Room) MakeGuess(playerID, guess string) (*api.GuessResponse, error) {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
+	if r.expired {
+		r.metrics.rejectedGuess(modeRoom, rejectExpired)
+		return nil, ErrRoomExpired
+	}
 	if r.Status != RoomPlaying {
-		return nil, fmt.Errorf("game not in progress")
+		r.metrics.rejectedGuess(modeRoom, rejectNotPlaying)
+		return nil, ErrRoomNotPlaying
 	}
 
 	player, exists := r.Players[playerID]
 	if !exists {
-		return nil, fmt.Errorf("player not found")
+		r.metrics.rejectedGuess(modeRoom, rejectNotPlaying)
+		return nil, ErrNotInRoom
 	}
 
 	if player.Status != PlayerPlaying {
-		return nil, fmt.Errorf("player already finished")
+		r.metrics.rejectedGuess(modeRoom, rejectGameOver)
+		return nil, ErrPlayerFinished
 	}
 
 	// Make the guess
 	result, err := player.Game.MakeGuess(guess)
 	if err != nil {
+		r.metrics.rejectedGuess(modeRoom, rejectReason(err))
 		return nil, err
 	}
+	r.metrics.guess(modeRoom, player.Game.GetStatus(), player.Game.CurrentRound)
 
 	// Convert to API response
 	response := &api.GuessResponse{
@@ -275,11 +534,50 @@ func (r *Room) MakeGuess(playerID, guess string) (*api.GuessResponse, error) {
 	}
 
 	player.History = append(player.History, *response)
-	r.notifyUpdate()
+
+	events := []api.RoomEvent{{
+		Type:     api.EventGuessMade,
+		PlayerID: playerID,
+		Guess:    response,
+	}}
+	if player.Status != PlayerPlaying {
+		events = append(events, api.RoomEvent{
+			Type:      api.EventPlayerFinished,
+			PlayerID:  playerID,
+			Status:    string(player.Status),
+			Timestamp: player.FinishTime,
+		})
+	}
+	if r.Status == RoomFinished {
+		winner, ranking := r.calculateRanking()
+		events = append(events, api.RoomEvent{
+			Type:    api.EventRoomFinished,
+			Winner:  winner,
+			Ranking: ranking,
+			Answer:  r.Answer,
+		})
+	}
+	r.notifyUpdate(events...)
 
 	return response, nil
 }
 
+// RemainingCandidates returns the answers still consistent with a player's guesses
+func (r *Room) RemainingCandidates(playerID string) ([]string, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	player, exists := r.Players[playerID]
+	if !exists {
+		return nil, ErrNotInRoom
+	}
+	if player.Game == nil {
+		return nil, ErrRoomNotPlaying
+	}
+
+	return player.Game.RemainingCandidates(), nil
+}
+
 // checkGameEnd checks if game should end (must be called with lock held)
 func (r *Room) checkGameEnd() {
 	allFinished := true
@@ -347,6 +645,27 @@ func (r *Room) GetProgress() *api.RoomProgressResponse {
 	return response
 }
 
+// ProgressSince returns the changes made after version as a delta, or the
+// full progress if the event log no longer covers them
+func (r *Room) ProgressSince(version int) *api.RoomProgressResponse {
+	r.mu.RLock()
+	events, complete := r.eventsSince(version)
+	if !complete {
+		r.mu.RUnlock()
+		return r.GetProgress()
+	}
+	defer r.mu.RUnlock()
+
+	return &api.RoomProgressResponse{
+		RoomID:    r.ID,
+		Status:    string(r.Status),
+		Version:   r.Version,
+		Timestamp: time.Now().Unix(),
+		Delta:     true,
+		Events:    events,
+	}
+}
+
 // calculateRanking calculates the final ranking (must be called with lock held)
 func (r *Room) calculateRanking() (winner string, ranking []string) {
 	// Sort players by: 1. Won > Lost, 2. Fewer rounds, 3. Earlier finish time
@@ -407,12 +726,184 @@ func (r *Room) calculateRanking() (winner string, ranking []string) {
 	return winner, ranking
 }
 
-// notifyUpdate increments version and broadcasts to all waiting clients
+// WaitForUpdate blocks until the room version differs from version or the
+// room expires, returning true, or until ctx is done, returning false
+// Waiters select on the room's current update channel, so a timeout only
+// wakes the waiter whose context ended.
+func (r *Room) WaitForUpdate(ctx context.Context, version int) bool {
+	r.mu.RLock()
+	if r.Version != version || r.expired {
+		r.mu.RUnlock()
+		return true
+	}
+	updated := r.updated
+	r.mu.RUnlock()
+
+	select {
+	case <-updated:
+		// Every broadcast follows a version bump or expiry
+		return true
+	case <-ctx.Done():
+		return r.changedSince(version)
+	}
+}
+
+// EventsSince returns the events recorded after version
+// complete is false when some of them have been dropped from the log, in
+// which case the caller must fall back to a full progress snapshot.
+func (r *Room) EventsSince(version int) (events []api.RoomEvent, complete bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.eventsSince(version)
+}
+
+// eventsSince is EventsSince without locking (must be called with lock held)
+func (r *Room) eventsSince(version int) (events []api.RoomEvent, complete bool) {
+	if version >= r.Version {
+		return nil, version == r.Version
+	}
+	if len(r.events) == 0 || r.events[0].Version > version+1 {
+		return nil, false
+	}
+
+	i := sort.Search(len(r.events), func(i int) bool {
+		return r.events[i].Version > version
+	})
+	return slices.Clone(r.events[i:]), true
+}
+
+// changedSince reports whether the room changed after version or expired
+func (r *Room) changedSince(version int) bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.Version != version || r.expired
+}
+
+// notifyUpdate increments version, records the change's events and
+// broadcasts to all waiting clients
 // Must be called with write lock held
-func (r *Room) notifyUpdate() {
+func (r *Room) notifyUpdate(events ...api.RoomEvent) {
 	r.Version++
-	// Broadcast wakes up all goroutines waiting on the condition variable
-	r.updateCond.Broadcast()
+	r.lastActive = time.Now()
+	for _, event := range events {
+		event.Version = r.Version
+		if event.Timestamp == 0 {
+			event.Timestamp = r.lastActive.Unix()
+		}
+		r.events = append(r.events, event)
+	}
+	if len(r.events) > maxRoomEvents {
+		r.events = slices.Clone(r.events[len(r.events)-maxRoomEvents:])
+	}
+	r.persist()
+	r.broadcast()
+}
+
+// broadcast wakes every waiter by closing the current update channel
+// Must be called with write lock held
+func (r *Room) broadcast() {
+	close(r.updated)
+	r.updated = make(chan struct{})
+}
+
+// persist writes the room through to the store (must be called with lock held)
+func (r *Room) persist() {
+	if r.store == nil || r.expired {
+		return
+	}
+
+	record := &RoomRecord{
+		ID:         r.ID,
+		Host:       r.Host,
+		Answer:     r.Answer,
+		MaxRounds:  r.MaxRounds,
+		WordLength: r.WordLength,
+		HardMode:   r.HardMode,
+		MaxPlayers: r.MaxPlayers,
+		Status:     r.Status,
+		Players:    make([]*PlayerRecord, 0, len(r.PlayerOrder)),
+		Version:    r.Version,
+		Events:     r.events,
+		LastActive: r.lastActive,
+	}
+	for _, playerID := range r.PlayerOrder {
+		player := r.Players[playerID]
+		record.Players = append(record.Players, &PlayerRecord{
+			ID:         player.ID,
+			Nickname:   player.Nickname,
+			Status:     player.Status,
+			Game:       player.Game,
+			History:    player.History,
+			FinishTime: player.FinishTime,
+			TokenHash:  player.tokenHash,
+		})
+	}
+
+	if err := r.store.SaveRoom(record); err != nil {
+		slog.Error("failed to save room", "room_id", r.ID, "err", err)
+	}
+}
+
+// expire marks the room expired, removes it from the store and wakes every
+// long-polling waiter so it can report the expiry
+// It returns false if the room was active again since the janitor checked it.
+func (r *Room) expire(ttl TTLs, now time.Time) (string, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	reason, expired := r.expiryReason(ttl, now)
+	if !expired {
+		return "", false
+	}
+	r.expired = true
+	if r.store != nil {
+		if err := r.store.DeleteRoom(r.ID); err != nil {
+			slog.Error("failed to delete room", "room_id", r.ID, "err", err)
+		}
+	}
+	r.broadcast()
+	return reason, true
+}
+
+// expiryReason reports whether the room has outlived its TTL and why
+// (must be called with lock held)
+func (r *Room) expiryReason(ttl TTLs, now time.Time) (string, bool) {
+	switch {
+	case len(r.Players) == 0:
+		return ReasonEmptyRoom, ttl.expired(ttl.EmptyRoom, r.lastActive, now)
+	case r.Status == RoomWaiting:
+		return ReasonWaitingRoom, ttl.expired(ttl.WaitingRoom, r.lastActive, now)
+	case r.Status == RoomFinished:
+		return ReasonFinishedRoom, ttl.expired(ttl.FinishedGame, r.lastActive, now)
+	default:
+		return ReasonIdleRoom, ttl.expired(ttl.IdleGame, r.lastActive, now)
+	}
+}
+
+// IsExpired reports whether the janitor has removed the room
+func (r *Room) IsExpired() bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.expired
+}
+
+// addListener counts a client waiting on the room, unless it already has
+// limit of them; a limit of 0 or less is unlimited
+func (r *Room) addListener(limit int) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if limit > 0 && r.listeners >= limit {
+		return false
+	}
+	r.listeners++
+	return true
+}
+
+// removeListener undoes addListener
+func (r *Room) removeListener() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.listeners--
 }
 
 // GetStatus returns the room status
@@ -431,6 +922,8 @@ func (r *Room) GetStatus() *api.RoomStatusResponse {
 		PlayerCount: len(r.Players),
 		MaxPlayers:  r.MaxPlayers,
 		MaxRounds:   r.MaxRounds,
+		WordLength:  r.WordLength,
+		HardMode:    r.HardMode,
 		Players:     playerNames,
 		Host:        r.Host,
 	}
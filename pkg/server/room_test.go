@@ -0,0 +1,172 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/admin/wordle/pkg/api"
+)
+
+// newBenchRoom returns a waiting room with one player
+func newBenchRoom(b *testing.B) *Room {
+	b.Helper()
+	rm := NewRoomManager(nil)
+	room, err := rm.CreateRoom("player-host", "host", hashToken("token"), RoomSettings{
+		MaxPlayers: 2,
+		MaxRounds:  6,
+		WordLength: 5,
+		WordList:   []string{"CRANE"},
+	})
+	if err != nil {
+		b.Fatalf("CreateRoom() error = %v", err)
+	}
+	return room
+}
+
+// parkPollers starts n long polls on room that wait until ctx is done
+// and returns a WaitGroup that is done when they have all returned
+func parkPollers(ctx context.Context, room *Room, n int) *sync.WaitGroup {
+	version := room.GetProgress().Version
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			room.WaitForUpdate(ctx, version)
+		}()
+	}
+	// Give the pollers time to park
+	time.Sleep(100 * time.Millisecond)
+	return &wg
+}
+
+// BenchmarkWaitForUpdateTimeout measures one long poll timing out while
+// thousands of others are parked on the same room
+func BenchmarkWaitForUpdateTimeout(b *testing.B) {
+	for _, pollers := range []int{100, 1000, 5000} {
+		b.Run(fmt.Sprintf("pollers=%d", pollers), func(b *testing.B) {
+			room := newBenchRoom(b)
+			ctx, cancel := context.WithCancel(context.Background())
+			wg := parkPollers(ctx, room, pollers)
+			version := room.GetProgress().Version
+
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				timeout, cancelTimeout := context.WithCancel(context.Background())
+				cancelTimeout()
+				if room.WaitForUpdate(timeout, version) {
+					b.Fatal("WaitForUpdate() = true, want false with no update")
+				}
+			}
+			b.StopTimer()
+
+			cancel()
+			wg.Wait()
+		})
+	}
+}
+
+// BenchmarkWaitForUpdateBroadcast measures waking thousands of parked long
+// polls with one room update
+func BenchmarkWaitForUpdateBroadcast(b *testing.B) {
+	for _, pollers := range []int{100, 1000, 5000} {
+		b.Run(fmt.Sprintf("pollers=%d", pollers), func(b *testing.B) {
+			room := newBenchRoom(b)
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			// Each poller waits for the next version, over and over
+			var woken sync.WaitGroup
+			start := room.GetProgress().Version
+			for i := 0; i < pollers; i++ {
+				go func() {
+					for version := start; room.WaitForUpdate(ctx, version); version++ {
+						woken.Done()
+					}
+				}()
+			}
+			time.Sleep(100 * time.Millisecond)
+
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				woken.Add(pollers)
+				room.mu.Lock()
+				room.notifyUpdate(api.RoomEvent{Type: api.EventPlayerJoined})
+				room.mu.Unlock()
+				woken.Wait()
+			}
+		})
+	}
+}
+
+func TestProgressSince(t *testing.T) {
+	// Versions: guest joined (1), game started (2), host guessed (3)
+	newRoom := func(t *testing.T) *Room {
+		room, err := NewRoomManager(nil).CreateRoom("player-host", "host", hashToken("host"), RoomSettings{
+			MaxPlayers: 2,
+			MaxRounds:  6,
+			WordLength: 5,
+			WordList:   []string{"CRANE"},
+		})
+		if err != nil {
+			t.Fatalf("CreateRoom() error = %v", err)
+		}
+		if err := room.JoinRoom("player-guest", "guest", hashToken("guest")); err != nil {
+			t.Fatalf("JoinRoom() error = %v", err)
+		}
+		if err := room.StartGame("player-host"); err != nil {
+			t.Fatalf("StartGame() error = %v", err)
+		}
+		if _, err := room.MakeGuess("player-host", "SLATE"); err != nil {
+			t.Fatalf("MakeGuess() error = %v", err)
+		}
+		return room
+	}
+
+	tests := []struct {
+		name         string
+		since        int
+		truncate     bool  // Drop the oldest event from the log
+		wantVersions []int // Event versions; nil expects the full progress
+	}{
+		{"up to date", 3, false, []int{}},
+		{"one behind", 2, false, []int{3}},
+		{"from the start", 0, false, []int{1, 2, 3}},
+		{"missed events dropped from the log", 0, true, nil},
+		{"older events dropped from the log", 1, true, []int{2, 3}},
+		{"version ahead of the room", 5, false, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			room := newRoom(t)
+			if tt.truncate {
+				room.events = room.events[1:]
+			}
+
+			progress := room.ProgressSince(tt.since)
+			if progress.Version != 3 {
+				t.Errorf("Version = %d, want 3", progress.Version)
+			}
+			if tt.wantVersions == nil {
+				if progress.Delta || len(progress.Players) != 2 {
+					t.Errorf("ProgressSince(%d) = %+v, want the full progress", tt.since, progress)
+				}
+				return
+			}
+
+			versions := []int{}
+			for _, event := range progress.Events {
+				versions = append(versions, event.Version)
+			}
+			if !progress.Delta || progress.Players != nil || !slices.Equal(versions, tt.wantVersions) {
+				t.Errorf("ProgressSince(%d) = delta %v with players %v and event versions %v; want a delta with versions %v",
+					tt.since, progress.Delta, progress.Players, versions, tt.wantVersions)
+			}
+		})
+	}
+}
@@ -2,104 +2,385 @@ package server
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io"
+	"log/slog"
 	"net/http"
 	"strconv"
+	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/admin/wordle/internal/config"
 	"github.com/admin/wordle/internal/game"
+	"github.com/admin/wordle/internal/solver"
 	"github.com/admin/wordle/pkg/api"
 	"github.com/gin-gonic/gin"
 )
 
 // Server represents the Wordle game server
 type Server struct {
-	sessions    map[string]*GameSession
-	roomManager *RoomManager
-	config      *config.Config
-	mu          sync.RWMutex
-	idCounter   int
+	sessions      map[string]*GameSession
+	roomManager   *RoomManager
+	config        *config.Config
+	dictionary    game.Dictionary    // Allowed guesses; nil accepts any valid word
+	normalization game.Normalization // Language rules applied to guesses
+	store         Store
+	ttl           TTLs                 // How long abandoned games and rooms are kept
+	expired       map[string]time.Time // Recently expired game IDs, for "expired" errors
+	janitor       janitorStats
+	metrics       *metrics
+	clientLimiter *rateLimiter       // Requests per client IP; nil is unlimited
+	tokenLimiter  *rateLimiter       // Requests per player token; nil is unlimited
+	shuttingDown  atomic.Bool        // No new games or rooms once set
+	waiters       context.Context    // Ended by ReleaseWaiters
+	releaseAll    context.CancelFunc // Ends waiters
+	sockets       sync.WaitGroup     // Open room WebSockets, drained on shutdown
+	mu            sync.RWMutex
 }
 
-// NewServer creates a new game server
-func NewServer(cfg *config.Config) *Server {
-	return &Server{
-		sessions:    make(map[string]*GameSession),
-		roomManager: NewRoomManager(),
-		config:      cfg,
+// NewServer creates a new game server, reloading the sessions and rooms saved in store
+func NewServer(cfg *config.Config, store Store) (*Server, error) {
+	s := &Server{
+		sessions:      make(map[string]*GameSession),
+		roomManager:   NewRoomManager(store),
+		config:        cfg,
+		dictionary:    cfg.Dictionary(),
+		normalization: cfg.Normalization(),
+		store:         store,
+		ttl:           NewTTLs(cfg),
+		expired:       make(map[string]time.Time),
+		janitor:       janitorStats{reclaimed: make(map[string]int64)},
+		clientLimiter: newRateLimiter(cfg.RateLimit, cfg.RateBurst),
+		tokenLimiter:  newRateLimiter(cfg.TokenRateLimit, cfg.TokenRateBurst),
+	}
+	s.waiters, s.releaseAll = context.WithCancel(context.Background())
+	s.metrics = newMetrics(s)
+	s.roomManager.SetMaxRooms(cfg.MaxRooms)
+	s.roomManager.SetMetrics(s.metrics)
+	if err := s.restore(); err != nil {
+		return nil, fmt.Errorf("failed to restore saved games: %w", err)
+	}
+	return s, nil
+}
+
+// restore reloads saved sessions and rooms from the store
+// Word lists and the dictionary are not saved; they are reattached from the
+// configuration. Room versions are kept so long-polling clients carry on.
+func (s *Server) restore() error {
+	sessions, rooms, err := s.store.Load()
+	if err != nil {
+		return err
+	}
+
+	for _, record := range sessions {
+		session := &GameSession{
+			ID:         record.ID,
+			Game:       record.Game,
+			Multi:      record.Multi,
+			History:    record.History,
+			lastActive: record.LastActive,
+			store:      s.store,
+			metrics:    s.metrics,
+		}
+		if session.lastActive.IsZero() {
+			session.lastActive = time.Now()
+		}
+		if session.Game == nil && (session.Multi == nil || len(session.Multi.Boards) == 0) {
+			return fmt.Errorf("game %s has no boards", record.ID)
+		}
+		if session.History == nil {
+			session.History = []api.GuessResponse{}
+		}
+		for _, g := range session.games() {
+			g.WordList = game.FilterWords(s.config.WordList, g.WordLength)
+			g.Dictionary = s.dictionary
+		}
+		s.sessions[session.ID] = session
 	}
+
+	for _, record := range rooms {
+		s.roomManager.RestoreRoom(record, RoomSettings{
+			WordList:      s.config.WordList,
+			Dictionary:    s.dictionary,
+			Normalization: s.normalization,
+		})
+	}
+
+	return nil
 }
 
 // HandleNewGame handles the creation of a new game
 func (s *Server) HandleNewGame(c *gin.Context) {
-	// Server uses its own configuration only
+	s.createGame(c, func(req api.NewGameRequest) (*GameSession, error) {
+		if req.Boards > 1 {
+			m, err := game.NewMultiGame(req.Boards, s.config.WordLength, s.config.WordList)
+			if err != nil {
+				return nil, err
+			}
+			return NewMultiGameSession("", m), nil
+		}
+		var g *game.Game
+		var err error
+		if req.Mode == api.ModeAbsurdle {
+			g, err = game.NewAdversarialGame(s.config.WordLength, s.config.WordList)
+		} else {
+			g, err = game.NewGame(s.config.MaxRounds, s.config.WordLength, s.config.WordList)
+		}
+		if err != nil {
+			return nil, err
+		}
+		return NewGameSession("", g), nil
+	})
+}
+
+// HandleNewDailyGame handles the creation of today's daily puzzle game
+func (s *Server) HandleNewDailyGame(c *gin.Context) {
+	s.createGame(c, func(req api.NewGameRequest) (*GameSession, error) {
+		if req.Boards > 1 || (req.Mode != "" && req.Mode != api.ModeClassic) {
+			return nil, ErrDailyOptions
+		}
+		epoch, err := s.config.DailyEpochTime()
+		if err != nil {
+			return nil, err
+		}
+		g, err := game.NewDailyGame(s.config.MaxRounds, s.config.WordLength, s.config.WordList,
+			time.Now(), epoch, s.config.DailySeed)
+		if err != nil {
+			return nil, err
+		}
+		return NewGameSession("", g), nil
+	})
+}
+
+// createGame creates a game session using newGame and writes the response
+func (s *Server) createGame(c *gin.Context, newGame func(req api.NewGameRequest) (*GameSession, error)) {
+	if !s.acceptingGames(c) {
+		return
+	}
+
+	// Request body is optional - omitted fields use the server configuration
+	var req api.NewGameRequest
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		badRequest(c, "Invalid request body")
+		return
+	}
+
+	switch req.Mode {
+	case "", api.ModeClassic, api.ModeAbsurdle:
+	default:
+		badRequest(c, fmt.Sprintf("Unknown game mode: %s", req.Mode))
+		return
+	}
+
+	if req.Boards < 0 || req.Boards > game.MaxBoards {
+		badRequest(c, fmt.Sprintf("Number of boards must be between 1 and %d", game.MaxBoards))
+		return
+	}
+	if req.Boards > 1 && req.Mode == api.ModeAbsurdle {
+		badRequest(c, "Absurdle mode has a single board")
+		return
+	}
+
 	// Create new game with server config
-	g, err := game.NewGame(s.config.MaxRounds, s.config.WordList)
+	session, err := newGame(req)
+	if errors.Is(err, ErrDailyOptions) {
+		writeError(c, err)
+		return
+	}
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, api.ErrorResponse{
-			Error: fmt.Sprintf("Failed to create game: %v", err),
-		})
+		internalError(c, fmt.Sprintf("Failed to create game: %v", err))
 		return
 	}
+	boards := session.games()
+	for _, g := range boards {
+		g.HardMode = s.hardMode(req.HardMode)
+		g.Dictionary = s.dictionary
+		g.Normalization = s.normalization
+	}
+
+	session.client = c.ClientIP()
+	session.store = s.store
+	session.metrics = s.metrics
 
-	// Generate game ID and create session
+	// Check the client's cap and register the session under one lock, so
+	// concurrent requests cannot all pass the cap
 	s.mu.Lock()
-	s.idCounter++
-	gameID := strconv.Itoa(s.idCounter)
-	session := NewGameSession(gameID, g)
+	if !s.withinGameLimit(c, session.client) {
+		s.mu.Unlock()
+		return
+	}
+	gameID := newGameID()
+	for s.gameIDTaken(gameID) {
+		gameID = newGameID()
+	}
+	session.ID = gameID
+	session.mu.Lock() // Until saved, so no guess is saved first
 	s.sessions[gameID] = session
 	s.mu.Unlock()
+	session.persist()
+	session.mu.Unlock()
+	s.metrics.gameCreated(session.metricsMode())
+	addLogAttrs(c, slog.String("game_id", gameID), slog.String("mode", session.metricsMode()))
 
+	g := boards[0]
+	maxRounds := g.MaxRounds
+	if session.Multi != nil {
+		maxRounds = session.Multi.MaxRounds
+	}
 	response := api.NewGameResponse{
-		GameID:    gameID,
-		MaxRounds: s.config.MaxRounds,
-		Message:   "Game created successfully",
+		GameID:       gameID,
+		Mode:         gameMode(g),
+		Boards:       len(boards),
+		MaxRounds:    maxRounds,
+		WordLength:   g.WordLength,
+		HardMode:     g.HardMode,
+		PuzzleNumber: g.PuzzleNumber,
+		PuzzleDate:   g.PuzzleDate,
+		Message:      "Game created successfully",
 	}
 
 	c.JSON(http.StatusCreated, response)
 }
 
-// HandleGuess handles a guess submission
-func (s *Server) HandleGuess(c *gin.Context) {
-	// Extract game ID from URL path parameter
-	gameID := c.Param("id")
+// gameIDTaken reports whether a game ID is in use or recently expired (must be called with lock held)
+func (s *Server) gameIDTaken(gameID string) bool {
+	_, exists := s.sessions[gameID]
+	_, expired := s.expired[gameID]
+	return exists || expired
+}
+
+// authenticatePlayer resolves the player acting on a room from the request's
+// token, writing a 401 or 403 when it fails. A player ID sent by the client
+// (claimed) must match the token's player. Protocol 1 clients send only the
+// player ID, which is accepted for players that joined without a token.
+func (s *Server) authenticatePlayer(c *gin.Context, room *Room, claimed string) (string, bool) {
+	token := bearerToken(c)
+	if token == "" && protocolVersion(c) < api.ProtocolVersion {
+		// Protocol 1 players have no token and name themselves
+		playerID, err := room.AuthenticateLegacy(claimed)
+		if err != nil {
+			writeError(c, err)
+			return "", false
+		}
+		addLogAttrs(c, slog.String("player_id", playerID))
+		return playerID, true
+	}
+
+	playerID, err := room.Authenticate(token)
+	if err != nil {
+		writeError(c, err)
+		return "", false
+	}
+	addLogAttrs(c, slog.String("player_id", playerID))
+	if claimed != "" && claimed != playerID {
+		writeError(c, ErrPlayerMismatch)
+		return "", false
+	}
+	return playerID, true
+}
+
+// findSession looks up a game session, writing a 404 (or 410 if it expired) when it is gone
+func (s *Server) findSession(c *gin.Context, gameID string) (*GameSession, bool) {
+	addLogAttrs(c, slog.String("game_id", gameID))
 
 	s.mu.RLock()
 	session, exists := s.sessions[gameID]
+	_, expired := s.expired[gameID]
 	s.mu.RUnlock()
 
+	if expired {
+		writeError(c, ErrGameExpired)
+		return nil, false
+	}
 	if !exists {
-		c.JSON(http.StatusNotFound, api.ErrorResponse{
-			Error: "Game not found",
-		})
+		writeError(c, ErrGameNotFound)
+		return nil, false
+	}
+	return session, true
+}
+
+// findRoom looks up a room, writing a 404 (or 410 if it expired) when it is gone
+func (s *Server) findRoom(c *gin.Context, roomID string) (*Room, bool) {
+	room, exists := s.roomManager.GetRoom(roomID)
+	addLogAttrs(c, slog.String("room_id", strings.ToUpper(roomID)))
+	if !exists && s.roomManager.IsExpired(roomID) {
+		writeError(c, ErrRoomExpired)
+		return nil, false
+	}
+	if !exists {
+		writeError(c, ErrRoomNotFound)
+		return nil, false
+	}
+	return room, true
+}
+
+// protocolVersion returns the protocol version the client asked for, echoing
+// it in the response; clients that do not say get the legacy version
+func protocolVersion(c *gin.Context) int {
+	version, err := strconv.Atoi(c.GetHeader(api.ProtocolHeader))
+	if err != nil || version < api.LegacyProtocolVersion {
+		version = api.LegacyProtocolVersion
+	}
+	version = min(version, api.ProtocolVersion)
+	c.Header(api.ProtocolHeader, strconv.Itoa(version))
+	return version
+}
+
+// newRoomPlayer returns the ID, secret token and token hash for a player
+// creating or joining a room. Protocol 1 clients predate tokens, so they get
+// none and act on the room by player ID alone.
+func newRoomPlayer(c *gin.Context) (playerID, token, tokenHash string) {
+	playerID = newPlayerID()
+	if protocolVersion(c) < api.ProtocolVersion {
+		return playerID, "", ""
+	}
+	token, tokenHash = newPlayerToken()
+	return playerID, token, tokenHash
+}
+
+// hardMode resolves a requested hard mode setting against the server default
+func (s *Server) hardMode(requested *bool) bool {
+	if requested != nil {
+		return *requested
+	}
+	return s.config.HardMode
+}
+
+// HandleGuess handles a guess submission
+func (s *Server) HandleGuess(c *gin.Context) {
+	// Extract game ID from URL path parameter
+	gameID := c.Param("id")
+
+	session, ok := s.findSession(c, gameID)
+	if !ok {
 		return
 	}
 
 	var req api.GuessRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, api.ErrorResponse{
-			Error: "Invalid request body",
-		})
+		badRequest(c, "Invalid request body")
 		return
 	}
 
 	// Validate input
-	if !game.ValidateWord(req.Guess) {
-		c.JSON(http.StatusBadRequest, api.ErrorResponse{
-			Error: "Invalid word: must be 5 letters, alphabetic only",
-		})
+	if !game.ValidateWordLength(req.Guess, session.WordLength()) {
+		s.metrics.rejectedGuess(session.metricsMode(), rejectInvalidWord)
+		err := fmt.Errorf("%w: must be %d letters, alphabetic only", game.ErrInvalidWord, session.WordLength())
+		logOutcome(c, "rejected", err)
+		writeError(c, err)
 		return
 	}
 
 	response, err := session.MakeGuess(req.Guess)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, api.ErrorResponse{
-			Error: err.Error(),
-		})
+		logOutcome(c, "rejected", err)
+		writeError(c, err)
 		return
 	}
+	logOutcome(c, response.GameStatus, nil)
 
 	c.JSON(http.StatusOK, response)
 }
@@ -109,14 +390,8 @@ func (s *Server) HandleStatus(c *gin.Context) {
 	// Extract game ID from URL path parameter
 	gameID := c.Param("id")
 
-	s.mu.RLock()
-	session, exists := s.sessions[gameID]
-	s.mu.RUnlock()
-
-	if !exists {
-		c.JSON(http.StatusNotFound, api.ErrorResponse{
-			Error: "Game not found",
-		})
+	session, ok := s.findSession(c, gameID)
+	if !ok {
 		return
 	}
 
@@ -124,50 +399,153 @@ func (s *Server) HandleStatus(c *gin.Context) {
 	c.JSON(http.StatusOK, status)
 }
 
+// HandleCandidates handles remaining-candidates requests
+// Only the count is returned unless the server is configured to list words
+func (s *Server) HandleCandidates(c *gin.Context) {
+	gameID := c.Param("id")
+
+	session, ok := s.findSession(c, gameID)
+	if !ok {
+		return
+	}
+
+	boards := session.RemainingCandidates()
+	response := s.candidatesResponse(boards[0])
+	if len(boards) > 1 {
+		response = api.CandidatesResponse{}
+		for _, words := range boards {
+			board := s.candidatesResponse(words)
+			response.Count += board.Count
+			response.Boards = append(response.Boards, api.BoardCandidates{
+				Count: board.Count,
+				Words: board.Words,
+			})
+		}
+	}
+	response.GameID = gameID
+
+	c.JSON(http.StatusOK, response)
+}
+
+// candidatesResponse builds a candidates response, listing the words only if configured
+func (s *Server) candidatesResponse(words []string) api.CandidatesResponse {
+	response := api.CandidatesResponse{Count: len(words)}
+	if s.config.ListCandidates {
+		response.Words = words
+	}
+	return response
+}
+
+// Suggestion limits for HandleSuggest
+const (
+	defaultSuggestions = 5
+	maxSuggestions     = 20
+)
+
+// HandleSuggest handles solver suggestion requests
+// Query parameters: strategy ("entropy" or "worst_case") and limit
+func (s *Server) HandleSuggest(c *gin.Context) {
+	if s.config.DisableSuggestions {
+		writeError(c, ErrSuggestionsDisabled)
+		return
+	}
+
+	gameID := c.Param("id")
+
+	session, ok := s.findSession(c, gameID)
+	if !ok {
+		return
+	}
+
+	strategy, err := solver.ParseStrategy(c.Query("strategy"))
+	if err != nil {
+		badRequest(c, fmt.Sprintf("Invalid strategy: %s", c.Query("strategy")))
+		return
+	}
+
+	limit := defaultSuggestions
+	if value := c.Query("limit"); value != "" {
+		limit, err = strconv.Atoi(value)
+		if err != nil || limit <= 0 || limit > maxSuggestions {
+			badRequest(c, fmt.Sprintf("Limit must be between 1 and %d", maxSuggestions))
+			return
+		}
+	}
+
+	response, err := session.Suggest(strategy, limit)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
 // ============================================
 // Multi-player Room API Handlers (Task 4)
 // ============================================
 
 // HandleCreateRoom handles room creation
 func (s *Server) HandleCreateRoom(c *gin.Context) {
+	if !s.acceptingGames(c) {
+		return
+	}
+
 	var req api.CreateRoomRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, api.ErrorResponse{
-			Error: "Invalid request body",
-		})
+		badRequest(c, "Invalid request body")
 		return
 	}
 
 	if req.Nickname == "" {
-		c.JSON(http.StatusBadRequest, api.ErrorResponse{
-			Error: "Nickname is required",
-		})
+		badRequest(c, "Nickname is required")
 		return
 	}
 
-	// Generate player ID
-	s.mu.Lock()
-	s.idCounter++
-	playerID := fmt.Sprintf("player-%d", s.idCounter)
-	s.mu.Unlock()
+	// Generate player ID and secret token
+	playerID, token, tokenHash := newRoomPlayer(c)
 
 	maxPlayers := req.MaxPlayers
 	if maxPlayers == 0 {
 		maxPlayers = 4
 	}
 
-	room, err := s.roomManager.CreateRoom(playerID, req.Nickname, maxPlayers, s.config.MaxRounds, s.config.WordList)
+	room, err := s.roomManager.CreateRoom(playerID, req.Nickname, tokenHash, RoomSettings{
+		MaxPlayers:    maxPlayers,
+		MaxRounds:     s.config.MaxRounds,
+		WordLength:    s.config.WordLength,
+		HardMode:      s.hardMode(req.HardMode),
+		WordList:      s.config.WordList,
+		Dictionary:    s.dictionary,
+		Normalization: s.normalization,
+	})
+	if errors.Is(err, ErrTooManyRooms) {
+		s.throttle(c, throttleRooms, retryAfter(err, roomCapRetry))
+		return
+	}
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, api.ErrorResponse{
-			Error: fmt.Sprintf("Failed to create room: %v", err),
-		})
+		internalError(c, fmt.Sprintf("Failed to create room: %v", err))
 		return
 	}
 
+	addLogAttrs(c, slog.String("room_id", room.ID), slog.String("player_id", playerID))
+	logOutcome(c, "created", nil)
+
+	message := "Room created! You are the host."
+	if token == "" {
+		// Version 1 clients scan their player ID out of this message
+		message = fmt.Sprintf("Room created! You are the host. Player ID: %s", playerID)
+	}
+
 	response := api.CreateRoomResponse{
-		RoomID:    room.ID,
-		MaxRounds: room.MaxRounds,
-		Message:   fmt.Sprintf("Room created! You are the host. Player ID: %s", playerID),
+		RoomID:     room.ID,
+		PlayerID:   playerID,
+		Token:      token,
+		IsHost:     true,
+		MaxRounds:  room.MaxRounds,
+		WordLength: room.WordLength,
+		HardMode:   room.HardMode,
+		Message:    message,
 	}
 
 	c.JSON(http.StatusCreated, response)
@@ -175,54 +553,59 @@ func (s *Server) HandleCreateRoom(c *gin.Context) {
 
 // HandleJoinRoom handles joining a room
 func (s *Server) HandleJoinRoom(c *gin.Context) {
+	if !s.acceptingGames(c) {
+		return
+	}
 	roomID := c.Param("id")
 
 	var req api.JoinRoomRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, api.ErrorResponse{
-			Error: "Invalid request body",
-		})
+		badRequest(c, "Invalid request body")
 		return
 	}
 
 	if req.Nickname == "" {
-		c.JSON(http.StatusBadRequest, api.ErrorResponse{
-			Error: "Nickname is required",
-		})
+		badRequest(c, "Nickname is required")
 		return
 	}
 
-	room, exists := s.roomManager.GetRoom(roomID)
-	if !exists {
-		c.JSON(http.StatusNotFound, api.ErrorResponse{
-			Error: "Room not found",
-		})
+	room, ok := s.findRoom(c, roomID)
+	if !ok {
 		return
 	}
 
-	// Generate player ID
-	s.mu.Lock()
-	s.idCounter++
-	playerID := fmt.Sprintf("player-%d", s.idCounter)
-	s.mu.Unlock()
+	// Generate player ID and secret token
+	playerID, token, tokenHash := newRoomPlayer(c)
 
-	err := room.JoinRoom(playerID, req.Nickname)
+	err := room.JoinRoom(playerID, req.Nickname, tokenHash)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, api.ErrorResponse{
-			Error: err.Error(),
-		})
+		logOutcome(c, "rejected", err)
+		writeError(c, err)
 		return
 	}
 
+	addLogAttrs(c, slog.String("player_id", playerID))
+	logOutcome(c, "joined", nil)
+
 	// Get player list
 	status := room.GetStatus()
 
+	message := "Joined room successfully!"
+	if token == "" {
+		// Version 1 clients scan their player ID out of this message
+		message = fmt.Sprintf("Joined room successfully! Player ID: %s", playerID)
+	}
+
 	response := api.JoinRoomResponse{
-		RoomID:    roomID,
-		MaxRounds: room.MaxRounds,
-		Players:   status.Players,
-		IsHost:    playerID == room.Host,
-		Message:   fmt.Sprintf("Joined room successfully! Player ID: %s", playerID),
+		RoomID:     room.ID,
+		PlayerID:   playerID,
+		Token:      token,
+		IsHost:     playerID == status.Host,
+		MaxRounds:  room.MaxRounds,
+		WordLength: room.WordLength,
+		HardMode:   room.HardMode,
+		Players:    status.Players,
+		Message:    message,
 	}
 
 	c.JSON(http.StatusOK, response)
@@ -231,66 +614,54 @@ func (s *Server) HandleJoinRoom(c *gin.Context) {
 // HandleLeaveRoom handles leaving a room
 func (s *Server) HandleLeaveRoom(c *gin.Context) {
 	roomID := c.Param("id")
-	playerID := c.Query("player_id")
 
-	if playerID == "" {
-		c.JSON(http.StatusBadRequest, api.ErrorResponse{
-			Error: "Player ID is required",
-		})
+	room, ok := s.findRoom(c, roomID)
+	if !ok {
 		return
 	}
 
-	room, exists := s.roomManager.GetRoom(roomID)
-	if !exists {
-		c.JSON(http.StatusNotFound, api.ErrorResponse{
-			Error: "Room not found",
-		})
+	playerID, ok := s.authenticatePlayer(c, room, c.Query("player_id"))
+	if !ok {
 		return
 	}
 
 	err := room.LeaveRoom(playerID)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, api.ErrorResponse{
-			Error: err.Error(),
-		})
+		logOutcome(c, "rejected", err)
+		writeError(c, err)
 		return
 	}
+	logOutcome(c, "left", nil)
 
-	c.JSON(http.StatusOK, gin.H{
-		"message": "Left room successfully",
+	c.JSON(http.StatusOK, api.MessageResponse{
+		Message: "Left room successfully",
 	})
 }
 
 // HandleStartRoom handles starting the game
 func (s *Server) HandleStartRoom(c *gin.Context) {
 	roomID := c.Param("id")
-	playerID := c.Query("player_id")
 
-	if playerID == "" {
-		c.JSON(http.StatusBadRequest, api.ErrorResponse{
-			Error: "Player ID is required",
-		})
+	room, ok := s.findRoom(c, roomID)
+	if !ok {
 		return
 	}
 
-	room, exists := s.roomManager.GetRoom(roomID)
-	if !exists {
-		c.JSON(http.StatusNotFound, api.ErrorResponse{
-			Error: "Room not found",
-		})
+	playerID, ok := s.authenticatePlayer(c, room, c.Query("player_id"))
+	if !ok {
 		return
 	}
 
 	err := room.StartGame(playerID)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, api.ErrorResponse{
-			Error: err.Error(),
-		})
+		logOutcome(c, "rejected", err)
+		writeError(c, err)
 		return
 	}
+	logOutcome(c, "started", nil)
 
-	c.JSON(http.StatusOK, gin.H{
-		"message": "Game started!",
+	c.JSON(http.StatusOK, api.MessageResponse{
+		Message: "Game started!",
 	})
 }
 
@@ -300,131 +671,152 @@ func (s *Server) HandleRoomGuess(c *gin.Context) {
 
 	var req api.RoomGuessRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, api.ErrorResponse{
-			Error: "Invalid request body",
-		})
+		badRequest(c, "Invalid request body")
 		return
 	}
 
-	// Validate input
-	if !game.ValidateWord(req.Guess) {
-		c.JSON(http.StatusBadRequest, api.ErrorResponse{
-			Error: "Invalid word: must be 5 letters, alphabetic only",
-		})
+	room, ok := s.findRoom(c, roomID)
+	if !ok {
 		return
 	}
 
-	room, exists := s.roomManager.GetRoom(roomID)
-	if !exists {
-		c.JSON(http.StatusNotFound, api.ErrorResponse{
-			Error: "Room not found",
-		})
+	playerID, ok := s.authenticatePlayer(c, room, req.PlayerID)
+	if !ok {
+		return
+	}
+
+	// Validate input
+	if !game.ValidateWordLength(req.Guess, room.WordLength) {
+		s.metrics.rejectedGuess(modeRoom, rejectInvalidWord)
+		err := fmt.Errorf("%w: must be %d letters, alphabetic only", game.ErrInvalidWord, room.WordLength)
+		logOutcome(c, "rejected", err)
+		writeError(c, err)
 		return
 	}
 
-	response, err := room.MakeGuess(req.PlayerID, req.Guess)
+	response, err := room.MakeGuess(playerID, req.Guess)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, api.ErrorResponse{
-			Error: err.Error(),
-		})
+		logOutcome(c, "rejected", err)
+		writeError(c, err)
 		return
 	}
+	logOutcome(c, response.GameStatus, nil)
 
 	c.JSON(http.StatusOK, response)
 }
 
+// HandleRoomCandidates handles remaining-candidates requests for a player in a room
+func (s *Server) HandleRoomCandidates(c *gin.Context) {
+	roomID := c.Param("id")
+
+	room, ok := s.findRoom(c, roomID)
+	if !ok {
+		return
+	}
+
+	playerID, ok := s.authenticatePlayer(c, room, c.Query("player_id"))
+	if !ok {
+		return
+	}
+
+	words, err := room.RemainingCandidates(playerID)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, s.candidatesResponse(words))
+}
+
 // HandleRoomProgress handles long polling for room progress
+// With ?since_version= it replies with only the changes after that version
+// (falling back to the full progress when they are no longer kept), and
+// waits on that version unless ?version= is also given.
 func (s *Server) HandleRoomProgress(c *gin.Context) {
 	roomID := c.Param("id")
 	versionStr := c.Query("version")
+	sinceStr := c.Query("since_version")
 
-	room, exists := s.roomManager.GetRoom(roomID)
-	if !exists {
-		c.JSON(http.StatusNotFound, api.ErrorResponse{
-			Error: "Room not found",
-		})
+	room, ok := s.findRoom(c, roomID)
+	if !ok {
 		return
 	}
 
-	// Parse version
-	lastVersion := 0
+	room.mu.RLock()
+	currentVersion := room.Version
+	room.mu.RUnlock()
+
+	// Parse versions
+	// A version the room has not reached would end every wait at once and
+	// leave the client polling in a loop, so it is refused.
+	parseVersion := func(name, value string) (int, bool) {
+		v, err := strconv.Atoi(value)
+		if err != nil || v < 0 || v > currentVersion {
+			badRequest(c, fmt.Sprintf("%s must be a room version from 0 to %d", name, currentVersion))
+			return 0, false
+		}
+		return v, true
+	}
+	sinceVersion := -1
+	if sinceStr != "" {
+		if sinceVersion, ok = parseVersion("since_version", sinceStr); !ok {
+			return
+		}
+	}
+	lastVersion := max(sinceVersion, 0)
 	if versionStr != "" {
-		if v, err := strconv.Atoi(versionStr); err == nil {
-			lastVersion = v
+		if lastVersion, ok = parseVersion("version", versionStr); !ok {
+			return
 		}
 	}
+	progress := func() *api.RoomProgressResponse {
+		if sinceVersion < 0 {
+			return room.GetProgress()
+		}
+		return room.ProgressSince(sinceVersion)
+	}
 
-	// Long polling implementation using sync.Cond
 	// Check if there's already an update
-	room.mu.RLock()
-	currentVersion := room.Version
-	room.mu.RUnlock()
-
 	if currentVersion > lastVersion {
-		progress := room.GetProgress()
-		c.JSON(http.StatusOK, progress)
+		c.JSON(http.StatusOK, progress())
 		return
 	}
 
-	// Wait for update or timeout using condition variable
-	// We use a separate goroutine to wait on the condition variable so we can
-	// simultaneously listen for timeout and client disconnect using select
-	ctx, cancel := context.WithTimeout(c.Request.Context(), 30*time.Second)
+	// Wait for update or timeout
+	if !s.addRoomListener(c, room) {
+		return
+	}
+	defer room.removeListener()
+	waitCtx, release := s.waitContext(c)
+	defer release()
+	ctx, cancel := context.WithTimeout(waitCtx, 30*time.Second)
 	defer cancel()
 
-	done := make(chan struct{})
-
-	go func() {
-		room.mu.Lock()
-		defer room.mu.Unlock()
-		defer close(done) // Always close done to prevent blocking
-
-		// Wait for version change or context cancellation
-		// Note: Wait() releases the lock while waiting and reacquires it on wakeup
-		for room.Version == lastVersion {
-			// Check if context is cancelled before waiting
-			select {
-			case <-ctx.Done():
-				return
-			default:
-			}
+	defer s.metrics.waiting()()
 
-			room.updateCond.Wait() // Releases lock, waits, then reacquires lock
-		}
-	}()
-
-	// Wait for version change or timeout
-	select {
-	case <-done:
-		// Version changed - return new progress
-		progress := room.GetProgress()
-		c.JSON(http.StatusOK, progress)
-
-	case <-ctx.Done():
-		// Timeout or client disconnected - wake up the waiting goroutine
-		room.updateCond.Broadcast()
-		<-done // Wait for goroutine to exit cleanly
-
-		// Check if it's a timeout or client disconnect
-		if c.Request.Context().Err() != nil {
-			// Client disconnected
-			return
+	if !room.WaitForUpdate(ctx, lastVersion) && waitCtx.Err() != nil {
+		if s.ShuttingDown() {
+			c.JSON(http.StatusOK, shutdownProgress(progress()))
 		}
-		// Timeout - return current state
-		progress := room.GetProgress()
-		c.JSON(http.StatusOK, progress)
+		// Otherwise the client disconnected
+		return
+	}
+
+	if room.IsExpired() {
+		writeError(c, ErrRoomExpired)
+		return
 	}
+
+	// Version changed, or timeout - return current state
+	c.JSON(http.StatusOK, progress())
 }
 
 // HandleRoomStatus handles room status requests
 func (s *Server) HandleRoomStatus(c *gin.Context) {
 	roomID := c.Param("id")
 
-	room, exists := s.roomManager.GetRoom(roomID)
-	if !exists {
-		c.JSON(http.StatusNotFound, api.ErrorResponse{
-			Error: "Room not found",
-		})
+	room, ok := s.findRoom(c, roomID)
+	if !ok {
 		return
 	}
 
@@ -448,3 +840,8 @@ func (s *Server) HandleListRooms(c *gin.Context) {
 
 	c.JSON(http.StatusOK, response)
 }
+
+// HandleOpenAPI serves the OpenAPI document for the API
+func (s *Server) HandleOpenAPI(c *gin.Context) {
+	c.Data(http.StatusOK, "application/json", api.OpenAPISpec)
+}
@@ -0,0 +1,170 @@
+package server
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/admin/wordle/pkg/api"
+)
+
+func TestNewDailyGameOptions(t *testing.T) {
+	app := newTestApp(t, nil)
+
+	tests := []struct {
+		name string
+		req  api.NewGameRequest
+		ok   bool
+	}{
+		{"defaults", api.NewGameRequest{}, true},
+		{"classic", api.NewGameRequest{Mode: api.ModeClassic}, true},
+		{"absurdle", api.NewGameRequest{Mode: api.ModeAbsurdle}, false},
+		{"multi-board", api.NewGameRequest{Boards: 2}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := serve(t, app, http.MethodPost, "/v1/daily/new", tt.req, nil)
+			if !tt.ok {
+				wantError(t, rec, http.StatusBadRequest, api.CodeUnsupported)
+				return
+			}
+			var resp api.NewGameResponse
+			decode(t, rec, &resp)
+			if rec.Code != http.StatusCreated || resp.PuzzleNumber == 0 {
+				t.Errorf("response = %d %+v, want 201 with a puzzle number", rec.Code, resp)
+			}
+		})
+	}
+}
+
+// TestLegacyRoomFlow plays a room the way version 1 clients did: no
+// protocol header, no token, player IDs in the body and query
+func TestLegacyRoomFlow(t *testing.T) {
+	app := newTestApp(t, nil)
+
+	// Version 1 clients send no protocol header and get no token
+	var host api.CreateRoomResponse
+	decode(t, serve(t, app, http.MethodPost, "/room/create", api.CreateRoomRequest{Nickname: "old host"}, nil), &host)
+	var guest api.JoinRoomResponse
+	decode(t, serve(t, app, http.MethodPost, "/room/"+host.RoomID+"/join", api.JoinRoomRequest{Nickname: "old guest"}, nil), &guest)
+	newer := joinRoom(t, app, host.RoomID)
+	for _, player := range []struct{ id, token, message string }{
+		{host.PlayerID, host.Token, host.Message},
+		{guest.PlayerID, guest.Token, guest.Message},
+	} {
+		if player.token != "" || !strings.HasSuffix(player.message, "Player ID: "+player.id) {
+			t.Errorf("token %q, message %q; want no token and the player ID %s in the message", player.token, player.message, player.id)
+		}
+	}
+
+	// They act by player ID alone
+	roomPath := "/room/" + host.RoomID
+	if rec := serve(t, app, http.MethodPost, roomPath+"/start?player_id="+host.PlayerID, nil, nil); rec.Code != http.StatusOK {
+		t.Fatalf("start = %d %s, want 200", rec.Code, rec.Body)
+	}
+	var guess api.GuessResponse
+	decode(t, serve(t, app, http.MethodPost, roomPath+"/guess", api.RoomGuessRequest{PlayerID: guest.PlayerID, Guess: testAnswer}, nil), &guess)
+	if guess.GameStatus != "won" {
+		t.Errorf("guess = %+v, want won", guess)
+	}
+
+	tests := []struct {
+		name       string
+		playerID   string
+		header     http.Header
+		wantStatus int
+		wantCode   string
+	}{
+		{"no player ID", "", nil, http.StatusUnauthorized, api.CodeUnauthorized},
+		{"unknown player", "player-unknown", nil, http.StatusBadRequest, api.CodeNotInRoom},
+		{"player with a token", newer.PlayerID, nil, http.StatusUnauthorized, api.CodeUnauthorized},
+		{"version 2 client without its token", host.PlayerID, v2(""), http.StatusUnauthorized, api.CodeUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := serve(t, app, http.MethodPost, roomPath+"/guess", api.RoomGuessRequest{PlayerID: tt.playerID, Guess: "SLATE"}, tt.header)
+			wantError(t, rec, tt.wantStatus, tt.wantCode)
+		})
+	}
+}
+
+func TestRoomFlow(t *testing.T) {
+	app := newTestApp(t, nil)
+
+	var host api.CreateRoomResponse
+	decode(t, serve(t, app, http.MethodPost, "/v1/room/create", api.CreateRoomRequest{Nickname: "host"}, v2("")), &host)
+	var guest api.JoinRoomResponse
+	decode(t, serve(t, app, http.MethodPost, "/v1/room/"+host.RoomID+"/join", api.JoinRoomRequest{Nickname: "guest"}, v2("")), &guest)
+	if !host.IsHost || guest.IsHost || host.Token == "" || guest.Token == "" || host.PlayerID == guest.PlayerID {
+		t.Fatalf("create = %+v, join = %+v; want a host and a guest with their own tokens", host, guest)
+	}
+
+	roomPath := "/v1/room/" + host.RoomID
+	wantError(t, serve(t, app, http.MethodPost, roomPath+"/start", nil, v2(guest.Token)), http.StatusBadRequest, api.CodeNotHost)
+	if rec := serve(t, app, http.MethodPost, roomPath+"/start", nil, v2(host.Token)); rec.Code != http.StatusOK {
+		t.Fatalf("start = %d %s, want 200", rec.Code, rec.Body)
+	}
+
+	// The guest's token cannot guess as the host
+	rec := serve(t, app, http.MethodPost, roomPath+"/guess", api.RoomGuessRequest{PlayerID: host.PlayerID, Guess: testAnswer}, v2(guest.Token))
+	wantError(t, rec, http.StatusForbidden, api.CodeForbidden)
+
+	var guess api.GuessResponse
+	decode(t, serve(t, app, http.MethodPost, roomPath+"/guess", api.RoomGuessRequest{Guess: testAnswer}, v2(guest.Token)), &guess)
+	if guess.GameStatus != "won" {
+		t.Errorf("guess = %+v, want won", guess)
+	}
+
+	var progress api.RoomProgressResponse
+	decode(t, serve(t, app, http.MethodGet, roomPath+"/progress", nil, nil), &progress)
+	if len(progress.Ranking) == 0 || progress.Ranking[0] != guest.PlayerID {
+		t.Errorf("ranking = %v, want the guest first", progress.Ranking)
+	}
+}
+
+func TestRoomProgressVersions(t *testing.T) {
+	app := newTestApp(t, nil)
+	var host api.CreateRoomResponse
+	decode(t, serve(t, app, http.MethodPost, "/v1/room/create", api.CreateRoomRequest{Nickname: "host"}, v2("")), &host)
+	serve(t, app, http.MethodPost, "/v1/room/"+host.RoomID+"/join", api.JoinRoomRequest{Nickname: "guest"}, v2(""))
+	var current api.RoomProgressResponse
+	decode(t, serve(t, app, http.MethodGet, "/v1/room/"+host.RoomID+"/progress", nil, nil), &current)
+	if current.Version == 0 {
+		t.Fatal("room version is still 0 after a join")
+	}
+	ahead := strconv.Itoa(current.Version + 1)
+
+	// None of these wait: each has a newer version or is refused
+	tests := []struct {
+		name      string
+		query     string
+		wantDelta bool
+		wantCode  string // Empty: 200
+	}{
+		{"no version", "", false, ""},
+		{"older version", "version=0", false, ""},
+		{"since an older version", "since_version=0", true, ""},
+		{"version ahead of the room", "version=" + ahead, false, api.CodeInvalidRequest},
+		{"since a version ahead of the room", "since_version=" + ahead, false, api.CodeInvalidRequest},
+		{"negative version", "version=-1", false, api.CodeInvalidRequest},
+		{"malformed since_version", "since_version=latest", false, api.CodeInvalidRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := serve(t, app, http.MethodGet, "/v1/room/"+host.RoomID+"/progress?"+tt.query, nil, nil)
+			if tt.wantCode != "" {
+				wantError(t, rec, http.StatusBadRequest, tt.wantCode)
+				return
+			}
+			var progress api.RoomProgressResponse
+			decode(t, rec, &progress)
+			if rec.Code != http.StatusOK || progress.Version != current.Version || progress.Delta != tt.wantDelta {
+				t.Errorf("progress = %d %+v, want version %d with delta %v", rec.Code, progress, current.Version, tt.wantDelta)
+			}
+		})
+	}
+}
@@ -1,26 +1,75 @@
 package server
 
 import (
+	"fmt"
+	"log/slog"
 	"sync"
+	"time"
 
 	"github.com/admin/wordle/internal/game"
+	"github.com/admin/wordle/internal/solver"
 	"github.com/admin/wordle/pkg/api"
 )
 
 // GameSession represents a server-side game session
+// Exactly one of Game and Multi is set
 type GameSession struct {
 	ID      string
 	Game    *game.Game
+	Multi   *game.MultiGame // Multi-board game
 	History []api.GuessResponse
-	mu      sync.RWMutex
+
+	lastActive time.Time // Time of the last guess, for expiry
+	expired    bool      // Removed by the janitor
+	client     string    // IP address that created the game, for max_games_per_client; not saved
+	suggested  suggestionCache
+	store      Store    // Write-through persistence; nil disables it
+	metrics    *metrics // nil counts nothing
+	mu         sync.RWMutex
 }
 
 // NewGameSession creates a new game session
 func NewGameSession(id string, g *game.Game) *GameSession {
 	return &GameSession{
-		ID:      id,
-		Game:    g,
-		History: []api.GuessResponse{},
+		ID:         id,
+		Game:       g,
+		History:    []api.GuessResponse{},
+		lastActive: time.Now(),
+	}
+}
+
+// NewMultiGameSession creates a new multi-board game session
+func NewMultiGameSession(id string, m *game.MultiGame) *GameSession {
+	return &GameSession{
+		ID:         id,
+		Multi:      m,
+		History:    []api.GuessResponse{},
+		lastActive: time.Now(),
+	}
+}
+
+// games returns every board in the session
+func (s *GameSession) games() []*game.Game {
+	if s.Multi != nil {
+		return s.Multi.Boards
+	}
+	return []*game.Game{s.Game}
+}
+
+// WordLength returns the word length of the session's game
+func (s *GameSession) WordLength() int {
+	return s.games()[0].WordLength
+}
+
+// metricsMode returns the session's game mode, as a metric label
+func (s *GameSession) metricsMode() string {
+	switch {
+	case s.Multi != nil:
+		return modeMultiboard
+	case s.Game.PuzzleNumber > 0:
+		return modeDaily
+	default:
+		return gameMode(s.Game)
 	}
 }
 
@@ -29,10 +78,22 @@ func (s *GameSession) MakeGuess(guess string) (*api.GuessResponse, error) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
+	if s.expired {
+		s.metrics.rejectedGuess(s.metricsMode(), rejectExpired)
+		return nil, ErrGameExpired
+	}
+	s.lastActive = time.Now()
+
+	if s.Multi != nil {
+		return s.makeMultiGuess(guess)
+	}
+
 	result, err := s.Game.MakeGuess(guess)
 	if err != nil {
+		s.metrics.rejectedGuess(s.metricsMode(), rejectReason(err))
 		return nil, err
 	}
+	s.metrics.guess(s.metricsMode(), s.Game.GetStatus(), s.Game.CurrentRound)
 
 	// Convert game.GuessResult to api.GuessResponse
 	response := &api.GuessResponse{
@@ -58,18 +119,125 @@ func (s *GameSession) MakeGuess(guess string) (*api.GuessResponse, error) {
 	}
 
 	s.History = append(s.History, *response)
+	s.persist()
 	return response, nil
 }
 
+// makeMultiGuess processes a guess on every board (must be called with lock held)
+func (s *GameSession) makeMultiGuess(guess string) (*api.GuessResponse, error) {
+	results, err := s.Multi.MakeGuess(guess)
+	if err != nil {
+		s.metrics.rejectedGuess(modeMultiboard, rejectReason(err))
+		return nil, err
+	}
+	s.metrics.guess(modeMultiboard, s.Multi.GetStatus(), s.Multi.CurrentRound)
+
+	response := &api.GuessResponse{
+		Guess:        s.Multi.Guesses[len(s.Multi.Guesses)-1],
+		GameOver:     s.Multi.IsGameOver(),
+		CurrentRound: s.Multi.CurrentRound,
+		MaxRounds:    s.Multi.MaxRounds,
+		Boards:       make([]api.BoardResult, len(s.Multi.Boards)),
+	}
+
+	for i, board := range s.Multi.Boards {
+		response.Boards[i].Solved = board.GetStatus() == game.Won
+		if len(results[i].Statuses) > 0 {
+			response.Boards[i].Results = convertToAPIResults(results[i])
+		}
+		if s.Multi.IsGameOver() {
+			response.Boards[i].Answer = board.Answer
+		}
+	}
+
+	switch s.Multi.GetStatus() {
+	case game.Won:
+		response.GameStatus = "won"
+		response.Message = fmt.Sprintf("Congratulations! You solved all %d boards!", len(s.Multi.Boards))
+	case game.Lost:
+		response.GameStatus = "lost"
+		response.Message = fmt.Sprintf("Game over! You solved %d of %d boards.", s.Multi.SolvedCount(), len(s.Multi.Boards))
+	default:
+		response.GameStatus = "in_progress"
+	}
+
+	s.History = append(s.History, *response)
+	s.persist()
+	return response, nil
+}
+
+// persist writes the session through to the store (must be called with lock held)
+func (s *GameSession) persist() {
+	if s.store == nil || s.expired {
+		return
+	}
+	err := s.store.SaveSession(&SessionRecord{
+		ID:         s.ID,
+		Game:       s.Game,
+		Multi:      s.Multi,
+		History:    s.History,
+		LastActive: s.lastActive,
+	})
+	if err != nil {
+		slog.Error("failed to save game", "game_id", s.ID, "err", err)
+	}
+}
+
+// isOver reports whether every board is finished (must be called with lock held)
+func (s *GameSession) isOver() bool {
+	if s.Multi != nil {
+		return s.Multi.IsGameOver()
+	}
+	return s.Game.IsGameOver()
+}
+
+// expire marks the session expired and removes it from the store
+// It returns false if the session was active again since the janitor checked it.
+func (s *GameSession) expire(ttl TTLs, now time.Time) (string, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	reason, expired := s.expiryReason(ttl, now)
+	if !expired {
+		return "", false
+	}
+	s.expired = true
+	if s.store != nil {
+		if err := s.store.DeleteSession(s.ID); err != nil {
+			slog.Error("failed to delete game", "game_id", s.ID, "err", err)
+		}
+	}
+	return reason, true
+}
+
+// expiryReason reports whether the session has outlived its TTL and why
+// (must be called with lock held)
+func (s *GameSession) expiryReason(ttl TTLs, now time.Time) (string, bool) {
+	if s.isOver() {
+		return ReasonFinishedGame, ttl.expired(ttl.FinishedGame, s.lastActive, now)
+	}
+	return ReasonIdleGame, ttl.expired(ttl.IdleGame, s.lastActive, now)
+}
+
 // GetStatus returns the current game status
 func (s *GameSession) GetStatus() *api.GameStatusResponse {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
+	if s.Multi != nil {
+		return s.getMultiStatus()
+	}
+
 	status := &api.GameStatusResponse{
 		GameID:       s.ID,
+		Mode:         gameMode(s.Game),
+		Boards:       1,
 		CurrentRound: s.Game.CurrentRound,
 		MaxRounds:    s.Game.MaxRounds,
+		WordLength:   s.Game.WordLength,
+		HardMode:     s.Game.HardMode,
+		PuzzleNumber: s.Game.PuzzleNumber,
+		PuzzleDate:   s.Game.PuzzleDate,
 		History:      s.History,
 	}
 
@@ -87,6 +255,108 @@ func (s *GameSession) GetStatus() *api.GameStatusResponse {
 	return status
 }
 
+// RemainingCandidates returns the answers still possible on each board
+func (s *GameSession) RemainingCandidates() [][]string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	candidates := [][]string{}
+	for _, g := range s.games() {
+		candidates = append(candidates, g.RemainingCandidates())
+	}
+	return candidates
+}
+
+// suggestionCache keeps a game's solver rankings until its next guess, so
+// repeated suggestion requests do not rank the guesses again
+type suggestionCache struct {
+	round    int // Guesses made when the rankings were computed
+	rankings map[solver.Strategy][]solver.Suggestion
+	mu       sync.Mutex
+}
+
+// get returns the best n suggestions for g with strategy
+func (c *suggestionCache) get(g *game.Game, strategy solver.Strategy, n int) []solver.Suggestion {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.rankings == nil || c.round != len(g.History) {
+		c.round = len(g.History)
+		c.rankings = make(map[solver.Strategy][]solver.Suggestion)
+	}
+	ranking, ok := c.rankings[strategy]
+	if !ok {
+		ranking = solver.Suggest(g, strategy, maxSuggestions)
+		c.rankings[strategy] = ranking
+	}
+	return ranking[:min(n, len(ranking))]
+}
+
+// Suggest ranks the next guesses for the session's game
+func (s *GameSession) Suggest(strategy solver.Strategy, n int) (*api.SuggestResponse, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if s.Multi != nil {
+		return nil, ErrSuggestionsUnsupported
+	}
+	if s.Game.IsGameOver() {
+		return nil, game.ErrGameOver
+	}
+
+	response := &api.SuggestResponse{
+		GameID:      s.ID,
+		Strategy:    strategy.String(),
+		Remaining:   len(s.Game.RemainingCandidates()),
+		Suggestions: []api.Suggestion{},
+	}
+	for _, suggestion := range s.suggested.get(s.Game, strategy, n) {
+		response.Suggestions = append(response.Suggestions, api.Suggestion{
+			Word:      suggestion.Word,
+			Entropy:   suggestion.Entropy,
+			WorstCase: suggestion.WorstCase,
+			Candidate: suggestion.Candidate,
+		})
+	}
+	return response, nil
+}
+
+// getMultiStatus returns the multi-board game status (must be called with lock held)
+func (s *GameSession) getMultiStatus() *api.GameStatusResponse {
+	first := s.Multi.Boards[0]
+	status := &api.GameStatusResponse{
+		GameID:       s.ID,
+		Mode:         api.ModeClassic,
+		Boards:       len(s.Multi.Boards),
+		CurrentRound: s.Multi.CurrentRound,
+		MaxRounds:    s.Multi.MaxRounds,
+		WordLength:   s.Multi.WordLength,
+		HardMode:     first.HardMode,
+		History:      s.History,
+	}
+
+	switch s.Multi.GetStatus() {
+	case game.Won:
+		status.GameStatus = "won"
+		status.Answers = s.Multi.Answers()
+	case game.Lost:
+		status.GameStatus = "lost"
+		status.Answers = s.Multi.Answers()
+	default:
+		status.GameStatus = "in_progress"
+	}
+
+	return status
+}
+
+// gameMode returns the API name of a game's mode
+func gameMode(g *game.Game) string {
+	if g.Adversarial {
+		return api.ModeAbsurdle
+	}
+	return api.ModeClassic
+}
+
 // convertToAPIResults converts game letter statuses to API format
 func convertToAPIResults(result game.GuessResult) []string {
 	results := make([]string, len(result.Statuses))
@@ -0,0 +1,75 @@
+package server
+
+import (
+	"context"
+	"errors"
+
+	"github.com/admin/wordle/pkg/api"
+	"github.com/gin-gonic/gin"
+)
+
+// ErrShuttingDown is returned for new games and rooms once shutdown has begun
+var ErrShuttingDown = errors.New("server shutting down")
+
+// BeginShutdown stops the server from taking new games and rooms and makes
+// /readyz report draining
+// Requests in flight, including those waiting on a room, carry on.
+func (s *Server) BeginShutdown() {
+	s.shuttingDown.Store(true)
+}
+
+// ReleaseWaiters ends every long poll, event stream and WebSocket, which
+// reply with shutdownProgress, so http.Server.Shutdown only has ordinary
+// requests left to drain
+func (s *Server) ReleaseWaiters() {
+	s.releaseAll()
+}
+
+// waitContext returns the context a long poll, event stream or WebSocket
+// waits on: the request's, ended early by ReleaseWaiters
+func (s *Server) waitContext(c *gin.Context) (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithCancel(c.Request.Context())
+	stop := context.AfterFunc(s.waiters, cancel)
+	return ctx, func() {
+		stop()
+		cancel()
+	}
+}
+
+// ShuttingDown reports whether shutdown has begun
+func (s *Server) ShuttingDown() bool {
+	return s.shuttingDown.Load()
+}
+
+// Close waits for open WebSockets to say goodbye, or for ctx to end,
+// then flushes and closes the store
+func (s *Server) Close(ctx context.Context) error {
+	done := make(chan struct{})
+	go func() {
+		s.sockets.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-ctx.Done():
+	}
+	return s.store.Close()
+}
+
+// acceptingGames writes a 503 once shutdown has begun, so clients go to
+// another server instead of starting a game that is about to stop
+func (s *Server) acceptingGames(c *gin.Context) bool {
+	if !s.ShuttingDown() {
+		return true
+	}
+	c.Header("Connection", "close")
+	writeError(c, ErrShuttingDown)
+	return false
+}
+
+// shutdownProgress marks progress as the last one before the server stops
+func shutdownProgress(progress *api.RoomProgressResponse) *api.RoomProgressResponse {
+	progress.Message = ErrShuttingDown.Error()
+	return progress
+}
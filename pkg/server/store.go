@@ -0,0 +1,149 @@
+package server
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/admin/wordle/internal/config"
+	"github.com/admin/wordle/internal/game"
+	"github.com/admin/wordle/pkg/api"
+)
+
+// Store persists game sessions and rooms so they survive a server restart
+// The server keeps live sessions and rooms in memory and writes every change
+// through to the store; on startup it reloads whatever the store returns.
+// Implementations must be safe for concurrent use.
+type Store interface {
+	SaveSession(record *SessionRecord) error
+	DeleteSession(id string) error
+	SaveRoom(record *RoomRecord) error
+	DeleteRoom(id string) error
+	// Load returns every saved session and room
+	Load() ([]*SessionRecord, []*RoomRecord, error)
+	// Ping checks that the store can still take writes
+	Ping() error
+	Close() error
+}
+
+// SessionRecord is the stored form of a GameSession
+// Games omit their word list and dictionary, which come from the configuration.
+type SessionRecord struct {
+	ID         string              `json:"id"`
+	Game       *game.Game          `json:"game,omitempty"`
+	Multi      *game.MultiGame     `json:"multi,omitempty"`
+	History    []api.GuessResponse `json:"history"`
+	LastActive time.Time           `json:"last_active"`
+}
+
+// RoomRecord is the stored form of a Room
+type RoomRecord struct {
+	ID         string          `json:"id"`
+	Host       string          `json:"host"`
+	Answer     string          `json:"answer"`
+	MaxRounds  int             `json:"max_rounds"`
+	WordLength int             `json:"word_length"`
+	HardMode   bool            `json:"hard_mode"`
+	MaxPlayers int             `json:"max_players"`
+	Status     RoomStatus      `json:"status"`
+	Players    []*PlayerRecord `json:"players"` // In join order
+	Version    int             `json:"version"`
+	Events     []api.RoomEvent `json:"events,omitempty"` // Recent events, for event streams
+	LastActive time.Time       `json:"last_active"`
+}
+
+// PlayerRecord is the stored form of a Player
+type PlayerRecord struct {
+	ID         string              `json:"id"`
+	Nickname   string              `json:"nickname"`
+	Status     PlayerStatus        `json:"status"`
+	Game       *game.Game          `json:"game,omitempty"`
+	History    []api.GuessResponse `json:"history"`
+	FinishTime int64               `json:"finish_time,omitempty"`
+	TokenHash  string              `json:"token_hash"`
+}
+
+// OpenStore opens the storage backend selected in the configuration
+func OpenStore(cfg *config.Config) (Store, error) {
+	switch cfg.Storage {
+	case "", config.StorageMemory:
+		return NewMemoryStore(), nil
+	case config.StorageFile:
+		return OpenFileStore(cfg.StoragePath)
+	default:
+		return nil, fmt.Errorf("unknown storage backend: %s", cfg.Storage)
+	}
+}
+
+// MemoryStore keeps records in memory only; nothing survives a restart
+type MemoryStore struct {
+	sessions map[string]*SessionRecord
+	rooms    map[string]*RoomRecord
+	mu       sync.Mutex
+}
+
+// NewMemoryStore creates an empty in-memory store
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		sessions: make(map[string]*SessionRecord),
+		rooms:    make(map[string]*RoomRecord),
+	}
+}
+
+// SaveSession stores a session record
+func (m *MemoryStore) SaveSession(record *SessionRecord) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.sessions[record.ID] = record
+	return nil
+}
+
+// DeleteSession removes a session record
+func (m *MemoryStore) DeleteSession(id string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.sessions, id)
+	return nil
+}
+
+// SaveRoom stores a room record
+func (m *MemoryStore) SaveRoom(record *RoomRecord) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.rooms[record.ID] = record
+	return nil
+}
+
+// DeleteRoom removes a room record
+func (m *MemoryStore) DeleteRoom(id string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.rooms, id)
+	return nil
+}
+
+// Load returns every stored session and room
+func (m *MemoryStore) Load() ([]*SessionRecord, []*RoomRecord, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	sessions := make([]*SessionRecord, 0, len(m.sessions))
+	for _, record := range m.sessions {
+		sessions = append(sessions, record)
+	}
+	rooms := make([]*RoomRecord, 0, len(m.rooms))
+	for _, record := range m.rooms {
+		rooms = append(rooms, record)
+	}
+	return sessions, rooms, nil
+}
+
+// Ping implements Store
+func (m *MemoryStore) Ping() error {
+	return nil
+}
+
+// Close implements Store
+func (m *MemoryStore) Close() error {
+	return nil
+}
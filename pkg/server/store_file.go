@@ -0,0 +1,387 @@
+package server
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// File names inside a FileStore directory
+const (
+	snapshotFile = "snapshot.json"
+	walFile      = "wal.jsonl"
+)
+
+// compactAfter is the number of log entries after which the log is folded into the snapshot
+const compactAfter = 1000
+
+// Write-ahead log operations
+const (
+	opSaveSession   = "save_session"
+	opDeleteSession = "delete_session"
+	opSaveRoom      = "save_room"
+	opDeleteRoom    = "delete_room"
+)
+
+// walEntry is one line of the write-ahead log
+type walEntry struct {
+	Op   string          `json:"op"`
+	ID   string          `json:"id"`
+	Data json.RawMessage `json:"data,omitempty"` // Record for save operations
+}
+
+// snapshot is the content of the snapshot file
+type snapshot struct {
+	Sessions map[string]json.RawMessage `json:"sessions"`
+	Rooms    map[string]json.RawMessage `json:"rooms"`
+}
+
+// FileStore persists records as a JSON snapshot plus a write-ahead log
+// Saves and deletes encode the record and queue it, so callers holding a
+// game or room lock never wait on the disk. A background writer appends
+// queued entries to the log and syncs them, batching everything queued
+// during one sync; a crash loses at most the entries not yet synced. Once
+// the log grows past compactAfter entries it is folded into a new snapshot.
+// A torn last line (from a crash mid-write) is ignored on load; a bad line
+// anywhere else fails the load.
+type FileStore struct {
+	dir      string
+	state    snapshot // Latest encoded record for every key, queued or not
+	pending  [][]byte // Log lines queued for the writer
+	closed   bool
+	writeErr error         // Last error from the writer, reported by Ping
+	flush    chan struct{} // Wakes the writer; buffered, so sends never block
+	done     chan struct{} // Closed when the writer has stopped
+	mu       sync.Mutex
+
+	wal      *os.File   // Owned by the writer, or by OpenFileStore and Close
+	walCount int        // Entries in the log since the last compaction
+	walMu    sync.Mutex // Held while the log or snapshot files are written
+}
+
+// OpenFileStore opens (or creates) a file store in dir and replays its log
+func OpenFileStore(dir string) (*FileStore, error) {
+	if dir == "" {
+		return nil, errors.New("storage path is required for the file backend")
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+
+	f := &FileStore{
+		dir: dir,
+		state: snapshot{
+			Sessions: make(map[string]json.RawMessage),
+			Rooms:    make(map[string]json.RawMessage),
+		},
+	}
+
+	if err := f.readSnapshot(); err != nil {
+		return nil, fmt.Errorf("failed to read snapshot: %w", err)
+	}
+	if err := f.replayLog(); err != nil {
+		return nil, fmt.Errorf("failed to replay log: %w", err)
+	}
+
+	// Start from a fresh snapshot and an empty log
+	if err := f.compact(); err != nil {
+		return nil, err
+	}
+
+	f.flush = make(chan struct{}, 1)
+	f.done = make(chan struct{})
+	go f.runWriter()
+	return f, nil
+}
+
+// readSnapshot loads the snapshot file, if any
+func (f *FileStore) readSnapshot() error {
+	data, err := os.ReadFile(filepath.Join(f.dir, snapshotFile))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	var loaded snapshot
+	if err := json.Unmarshal(data, &loaded); err != nil {
+		return err
+	}
+	for id, record := range loaded.Sessions {
+		f.state.Sessions[id] = record
+	}
+	for id, record := range loaded.Rooms {
+		f.state.Rooms[id] = record
+	}
+	return nil
+}
+
+// replayLog applies every entry of the write-ahead log
+// Only the last line can be torn by a crash, so it alone may be skipped.
+func (f *FileStore) replayLog() error {
+	file, err := os.Open(filepath.Join(f.dir, walFile))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	var torn error
+	for line := 1; scanner.Scan(); line++ {
+		if torn != nil {
+			return fmt.Errorf("corrupt entry on line %d: %w", line-1, torn)
+		}
+		var entry walEntry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			torn = err
+			continue
+		}
+		f.apply(entry)
+	}
+	return scanner.Err()
+}
+
+// apply updates the in-memory state with a log entry
+func (f *FileStore) apply(entry walEntry) {
+	switch entry.Op {
+	case opSaveSession:
+		f.state.Sessions[entry.ID] = entry.Data
+	case opDeleteSession:
+		delete(f.state.Sessions, entry.ID)
+	case opSaveRoom:
+		f.state.Rooms[entry.ID] = entry.Data
+	case opDeleteRoom:
+		delete(f.state.Rooms, entry.ID)
+	}
+}
+
+// compact writes the current state to a new snapshot and truncates the log
+// Must be called with f.walMu held (or before the store is shared)
+// Entries still queued are already in the state; writing them to the new
+// log as well is harmless, since replaying them sets the same records.
+func (f *FileStore) compact() error {
+	f.mu.Lock()
+	data, err := json.Marshal(f.state)
+	f.mu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	// Write the snapshot atomically: temp file, sync, rename
+	tmp := filepath.Join(f.dir, snapshotFile+".tmp")
+	if err := writeFileSync(tmp, data); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp, filepath.Join(f.dir, snapshotFile)); err != nil {
+		return err
+	}
+
+	if f.wal != nil {
+		f.wal.Close()
+	}
+	wal, err := os.OpenFile(filepath.Join(f.dir, walFile), os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o600)
+	if err != nil {
+		return err
+	}
+	f.wal = wal
+	f.walCount = 0
+	return nil
+}
+
+// writeFileSync writes data to a file and syncs it to disk
+func writeFileSync(path string, data []byte) error {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o600)
+	if err != nil {
+		return err
+	}
+	if _, err := file.Write(data); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Sync(); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+// write applies an entry and queues it for the log
+// The record is encoded before write returns, so the caller may change it.
+func (f *FileStore) write(op, id string, record any) error {
+	entry := walEntry{Op: op, ID: id}
+	if record != nil {
+		data, err := json.Marshal(record)
+		if err != nil {
+			return err
+		}
+		entry.Data = data
+	}
+
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.closed {
+		return errors.New("store is closed")
+	}
+	f.apply(entry)
+	f.pending = append(f.pending, append(line, '\n'))
+
+	select {
+	case f.flush <- struct{}{}:
+	default: // The writer is already due to run
+	}
+	return nil
+}
+
+// runWriter writes queued entries to the log until the store is closed
+func (f *FileStore) runWriter() {
+	defer close(f.done)
+	for range f.flush {
+		if err := f.writePending(); err != nil {
+			slog.Error("failed to write storage log", "dir", f.dir, "err", err)
+		}
+	}
+}
+
+// writePending appends the queued entries to the log with a single sync,
+// then compacts the log if it has grown too long
+func (f *FileStore) writePending() error {
+	f.walMu.Lock()
+	defer f.walMu.Unlock()
+
+	f.mu.Lock()
+	lines := f.pending
+	f.pending = nil
+	f.mu.Unlock()
+	if len(lines) == 0 || f.wal == nil {
+		return nil
+	}
+
+	err := f.appendLog(lines)
+	if err == nil && f.walCount >= compactAfter {
+		err = f.compact()
+	}
+	f.mu.Lock()
+	f.writeErr = err
+	f.mu.Unlock()
+	return err
+}
+
+// appendLog writes lines to the log and syncs it (must be called with
+// f.walMu held)
+func (f *FileStore) appendLog(lines [][]byte) error {
+	for _, line := range lines {
+		if _, err := f.wal.Write(line); err != nil {
+			return err
+		}
+	}
+	if err := f.wal.Sync(); err != nil {
+		return err
+	}
+	f.walCount += len(lines)
+	return nil
+}
+
+// SaveSession stores a session record
+func (f *FileStore) SaveSession(record *SessionRecord) error {
+	return f.write(opSaveSession, record.ID, record)
+}
+
+// DeleteSession removes a session record
+func (f *FileStore) DeleteSession(id string) error {
+	return f.write(opDeleteSession, id, nil)
+}
+
+// SaveRoom stores a room record
+func (f *FileStore) SaveRoom(record *RoomRecord) error {
+	return f.write(opSaveRoom, record.ID, record)
+}
+
+// DeleteRoom removes a room record
+func (f *FileStore) DeleteRoom(id string) error {
+	return f.write(opDeleteRoom, id, nil)
+}
+
+// Load decodes every stored session and room
+func (f *FileStore) Load() ([]*SessionRecord, []*RoomRecord, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	sessions := make([]*SessionRecord, 0, len(f.state.Sessions))
+	for id, data := range f.state.Sessions {
+		var record SessionRecord
+		if err := json.Unmarshal(data, &record); err != nil {
+			return nil, nil, fmt.Errorf("session %s: %w", id, err)
+		}
+		sessions = append(sessions, &record)
+	}
+
+	rooms := make([]*RoomRecord, 0, len(f.state.Rooms))
+	for id, data := range f.state.Rooms {
+		var record RoomRecord
+		if err := json.Unmarshal(data, &record); err != nil {
+			return nil, nil, fmt.Errorf("room %s: %w", id, err)
+		}
+		rooms = append(rooms, &record)
+	}
+
+	return sessions, rooms, nil
+}
+
+// Ping checks that the store is open, its last log write succeeded and
+// the directory is still writable
+func (f *FileStore) Ping() error {
+	f.mu.Lock()
+	closed, writeErr := f.closed, f.writeErr
+	f.mu.Unlock()
+	if closed {
+		return errors.New("store is closed")
+	}
+	if writeErr != nil {
+		return writeErr
+	}
+
+	probe, err := os.CreateTemp(f.dir, ".ping-*")
+	if err != nil {
+		return err
+	}
+	probe.Close()
+	return os.Remove(probe.Name())
+}
+
+// Close stops the writer and folds every change, written or still queued,
+// into the snapshot
+func (f *FileStore) Close() error {
+	f.mu.Lock()
+	if f.closed {
+		f.mu.Unlock()
+		return nil
+	}
+	f.closed = true
+	f.pending = nil // Already in the state the snapshot is written from
+	close(f.flush)
+	f.mu.Unlock()
+	<-f.done
+
+	f.walMu.Lock()
+	defer f.walMu.Unlock()
+	err := f.compact()
+	f.wal.Close()
+	f.wal = nil
+	return err
+}
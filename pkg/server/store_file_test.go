@@ -0,0 +1,240 @@
+package server
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/admin/wordle/internal/config"
+	"github.com/admin/wordle/internal/game"
+	"github.com/admin/wordle/pkg/api"
+)
+
+// openTestStore opens a file store in dir, failing the test on error
+func openTestStore(t *testing.T, dir string) *FileStore {
+	t.Helper()
+	f, err := OpenFileStore(dir)
+	if err != nil {
+		t.Fatalf("OpenFileStore() error = %v", err)
+	}
+	return f
+}
+
+// sessionRecord returns a record for a classic game with answer CRANE
+func sessionRecord(t *testing.T, id string) *SessionRecord {
+	t.Helper()
+	g, err := game.NewGameWithAnswer(6, testAnswer)
+	if err != nil {
+		t.Fatalf("NewGameWithAnswer() error = %v", err)
+	}
+	return &SessionRecord{ID: id, Game: g, History: []api.GuessResponse{}}
+}
+
+// storedIDs loads the store and returns the sorted session and room IDs
+func storedIDs(t *testing.T, store Store) ([]string, []string) {
+	t.Helper()
+	sessions, rooms, err := store.Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	var sessionIDs, roomIDs []string
+	for _, record := range sessions {
+		sessionIDs = append(sessionIDs, record.ID)
+	}
+	for _, record := range rooms {
+		roomIDs = append(roomIDs, record.ID)
+	}
+	slices.Sort(sessionIDs)
+	slices.Sort(roomIDs)
+	return sessionIDs, roomIDs
+}
+
+// logLines returns the lines of the write-ahead log in dir
+func logLines(t *testing.T, dir string) []string {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join(dir, walFile))
+	if err != nil {
+		t.Fatalf("ReadFile() error = %v", err)
+	}
+	return strings.Fields(string(data))
+}
+
+func TestFileStoreReopen(t *testing.T) {
+	tests := []struct {
+		name  string
+		close bool // Close cleanly, or stop as if the process crashed
+	}{
+		{"after close", true},
+		{"after crash", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			f := openTestStore(t, dir)
+			for _, id := range []string{"game-a", "game-b", "game-c"} {
+				if err := f.SaveSession(sessionRecord(t, id)); err != nil {
+					t.Fatalf("SaveSession() error = %v", err)
+				}
+			}
+			f.DeleteSession("game-b")
+			f.SaveRoom(&RoomRecord{ID: "ROOM01", Players: []*PlayerRecord{}})
+
+			if tt.close {
+				if err := f.Close(); err != nil {
+					t.Fatalf("Close() error = %v", err)
+				}
+			} else if err := f.writePending(); err != nil {
+				t.Fatalf("writePending() error = %v", err)
+			}
+
+			reopened := openTestStore(t, dir)
+			defer reopened.Close()
+			sessions, rooms := storedIDs(t, reopened)
+			if !slices.Equal(sessions, []string{"game-a", "game-c"}) || !slices.Equal(rooms, []string{"ROOM01"}) {
+				t.Errorf("reopened store has sessions %v and rooms %v, want [game-a game-c] and [ROOM01]", sessions, rooms)
+			}
+		})
+	}
+}
+
+func TestFileStoreReplayLog(t *testing.T) {
+	save := func(id string) string {
+		return fmt.Sprintf(`{"op":"save_session","id":%q,"data":{"id":%q,"history":[]}}`, id, id)
+	}
+
+	tests := []struct {
+		name    string
+		log     string
+		want    []string
+		wantErr bool
+	}{
+		{"complete", save("a") + "\n" + save("b") + "\n", []string{"a", "b"}, false},
+		{"delete", save("a") + "\n" + `{"op":"delete_session","id":"a"}` + "\n", nil, false},
+		{"torn last line", save("a") + "\n" + `{"op":"save_sess`, []string{"a"}, false},
+		{"corrupt line before the last", save("a") + "\n" + `{"op":"save_sess` + "\n" + save("b") + "\n", nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			if err := os.WriteFile(filepath.Join(dir, walFile), []byte(tt.log), 0o600); err != nil {
+				t.Fatal(err)
+			}
+
+			f, err := OpenFileStore(dir)
+			if tt.wantErr {
+				if err == nil {
+					f.Close()
+					t.Fatal("OpenFileStore() should fail on a corrupt log")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("OpenFileStore() error = %v", err)
+			}
+			defer f.Close()
+
+			if sessions, _ := storedIDs(t, f); !slices.Equal(sessions, tt.want) {
+				t.Errorf("sessions = %v, want %v", sessions, tt.want)
+			}
+			// The replayed log is folded into the snapshot
+			if lines := logLines(t, dir); len(lines) != 0 {
+				t.Errorf("log after open has %d lines, want 0", len(lines))
+			}
+		})
+	}
+}
+
+func TestFileStoreCompaction(t *testing.T) {
+	dir := t.TempDir()
+	f := openTestStore(t, dir)
+	defer f.Close()
+
+	record := sessionRecord(t, "game-a")
+	for i := 0; i < compactAfter+10; i++ {
+		f.SaveSession(record)
+		if err := f.writePending(); err != nil {
+			t.Fatalf("writePending() error = %v", err)
+		}
+	}
+
+	if lines := logLines(t, dir); len(lines) != 10 {
+		t.Errorf("log has %d lines after compaction, want 10", len(lines))
+	}
+	snapshot, err := os.ReadFile(filepath.Join(dir, snapshotFile))
+	if err != nil || !strings.Contains(string(snapshot), "game-a") {
+		t.Errorf("snapshot = %s, %v; want it to hold game-a", snapshot, err)
+	}
+}
+
+func TestFileStoreClosed(t *testing.T) {
+	f := openTestStore(t, t.TempDir())
+	if err := f.Ping(); err != nil {
+		t.Errorf("Ping() error = %v", err)
+	}
+	f.Close()
+	if err := f.Ping(); err == nil {
+		t.Error("Ping() after Close should return error")
+	}
+	if err := f.SaveSession(sessionRecord(t, "game-a")); err == nil {
+		t.Error("SaveSession() after Close should return error")
+	}
+	if err := f.Close(); err != nil {
+		t.Errorf("second Close() error = %v", err)
+	}
+}
+
+func TestServerRestore(t *testing.T) {
+	dir := t.TempDir()
+	useFileStore := func(cfg *config.Config) {
+		cfg.Storage = config.StorageFile
+		cfg.StoragePath = dir
+	}
+
+	// Play a little, then restart on the same directory
+	app := newTestApp(t, useFileStore)
+	var created api.NewGameResponse
+	decode(t, serve(t, app, http.MethodPost, "/v1/game/new", nil, nil), &created)
+	serve(t, app, http.MethodPost, "/v1/game/"+created.GameID+"/guess", api.GuessRequest{Guess: "SLATE"}, nil)
+
+	var room api.CreateRoomResponse
+	decode(t, serve(t, app, http.MethodPost, "/v1/room/create", api.CreateRoomRequest{Nickname: "host"}, v2("")), &room)
+	if err := app.server.Close(t.Context()); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	app = newTestApp(t, useFileStore)
+	var status api.GameStatusResponse
+	decode(t, serve(t, app, http.MethodGet, "/v1/game/"+created.GameID+"/status", nil, nil), &status)
+	if len(status.History) != 1 || status.History[0].Guess != "SLATE" || status.GameStatus != "in_progress" {
+		t.Errorf("restored game = %+v, want one SLATE guess, in progress", status)
+	}
+
+	// The host's token still works after the restart
+	rec := serve(t, app, http.MethodPost, "/v1/room/"+room.RoomID+"/leave", nil, bearer(room.Token))
+	if rec.Code != http.StatusOK {
+		t.Errorf("leave restored room = %d %s, want 200", rec.Code, rec.Body)
+	}
+}
+
+// BenchmarkFileStoreSaveSession measures what persist costs a caller
+// holding a game lock: encoding and queueing, without the disk
+func BenchmarkFileStoreSaveSession(b *testing.B) {
+	f, err := OpenFileStore(b.TempDir())
+	if err != nil {
+		b.Fatalf("OpenFileStore() error = %v", err)
+	}
+	defer f.Close()
+	g, _ := game.NewGameWithAnswer(6, testAnswer)
+	record := &SessionRecord{ID: "game-a", Game: g, History: []api.GuessResponse{}}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		f.SaveSession(record)
+	}
+}
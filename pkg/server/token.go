@@ -0,0 +1,92 @@
+package server
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"strings"
+
+	"github.com/admin/wordle/pkg/api"
+	"github.com/gin-gonic/gin"
+)
+
+// Errors returned when a room request is not authenticated
+var (
+	ErrMissingToken = errors.New("player token is required")
+	ErrInvalidToken = errors.New("invalid player token")
+)
+
+// roomIDAlphabet leaves out characters that are easy to misread (0/O, 1/I/L)
+// since players type room IDs by hand
+const roomIDAlphabet = "23456789ABCDEFGHJKMNPQRSTUVWXYZ"
+
+// Random ID and token sizes
+const (
+	roomIDLength   = 6  // Characters from roomIDAlphabet
+	gameIDBytes    = 8  // Hex-encoded
+	playerIDBytes  = 4  // Hex-encoded, after "player-"
+	playerTokenLen = 32 // Random bytes, base64url-encoded
+)
+
+// randomBytes returns n bytes from the system's secure random source
+func randomBytes(n int) []byte {
+	b := make([]byte, n)
+	// crypto/rand.Read never fails on supported platforms
+	if _, err := rand.Read(b); err != nil {
+		panic(err)
+	}
+	return b
+}
+
+// newGameID returns a random, unguessable game ID
+func newGameID() string {
+	return hex.EncodeToString(randomBytes(gameIDBytes))
+}
+
+// newRoomID returns a random room ID that is short enough to type
+func newRoomID() string {
+	id := make([]byte, roomIDLength)
+	for i, b := range randomBytes(roomIDLength) {
+		// 256 % 31 leaves a slight bias, which is fine for IDs
+		id[i] = roomIDAlphabet[int(b)%len(roomIDAlphabet)]
+	}
+	return string(id)
+}
+
+// newPlayerID returns a random player ID
+// Player IDs are public (they appear in progress and rankings); the token is the secret.
+func newPlayerID() string {
+	return "player-" + hex.EncodeToString(randomBytes(playerIDBytes))
+}
+
+// newPlayerToken returns a random opaque token and the hash the server keeps
+func newPlayerToken() (token, hash string) {
+	token = base64.RawURLEncoding.EncodeToString(randomBytes(playerTokenLen))
+	return token, hashToken(token)
+}
+
+// hashToken returns the stored form of a token
+// Only hashes are kept in memory and in storage, so a leaked snapshot
+// cannot be used to act as a player.
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// tokenMatches compares a token hash against a stored hash in constant time
+func tokenMatches(hash, stored string) bool {
+	return subtle.ConstantTimeCompare([]byte(hash), []byte(stored)) == 1
+}
+
+// bearerToken extracts the player token from the Authorization header
+func bearerToken(c *gin.Context) string {
+	header := c.GetHeader(api.AuthHeader)
+	token, ok := strings.CutPrefix(header, api.AuthScheme+" ")
+	if !ok {
+		return ""
+	}
+	return strings.TrimSpace(token)
+}
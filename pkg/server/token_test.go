@@ -0,0 +1,123 @@
+package server
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/admin/wordle/pkg/api"
+)
+
+func TestAuthenticate(t *testing.T) {
+	rm := NewRoomManager(nil)
+	hostToken, hostHash := newPlayerToken()
+	guestToken, guestHash := newPlayerToken()
+	room, err := rm.CreateRoom("player-host", "host", hostHash, RoomSettings{
+		MaxPlayers: 2,
+		MaxRounds:  6,
+		WordLength: 5,
+		WordList:   []string{testAnswer},
+	})
+	if err != nil {
+		t.Fatalf("CreateRoom() error = %v", err)
+	}
+	if err := room.JoinRoom("player-guest", "guest", guestHash); err != nil {
+		t.Fatalf("JoinRoom() error = %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		token   string
+		want    string
+		wantErr error
+	}{
+		{"host", hostToken, "player-host", nil},
+		{"guest", guestToken, "player-guest", nil},
+		{"no token", "", "", ErrMissingToken},
+		{"unknown token", "not-a-token", "", ErrInvalidToken},
+		{"stored hash", hostHash, "", ErrInvalidToken},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := room.Authenticate(tt.token)
+			if got != tt.want || !errors.Is(err, tt.wantErr) {
+				t.Errorf("Authenticate() = %q, %v; want %q, %v", got, err, tt.want, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestRoomAuthorization(t *testing.T) {
+	app := newTestApp(t, nil)
+	host := createRoom(t, app)
+	guest := joinRoom(t, app, host.RoomID)
+	roomPath := "/v1/room/" + host.RoomID
+
+	tests := []struct {
+		name       string
+		path       string // Under roomPath
+		header     http.Header
+		wantStatus int
+		wantCode   string // Empty for success
+	}{
+		{"no token", "/start", nil, http.StatusUnauthorized, api.CodeUnauthorized},
+		{"unknown token", "/start", bearer("not-a-token"), http.StatusUnauthorized, api.CodeUnauthorized},
+		{"other scheme", "/start", http.Header{"Authorization": {"Basic " + host.Token}}, http.StatusUnauthorized, api.CodeUnauthorized},
+		{"token from another room", "/start", bearer(createRoom(t, app).Token), http.StatusUnauthorized, api.CodeUnauthorized},
+		{"acting as another player", "/start?player_id=" + host.PlayerID, bearer(guest.Token), http.StatusForbidden, api.CodeForbidden},
+		{"guest starting", "/start", bearer(guest.Token), http.StatusBadRequest, api.CodeNotHost},
+		{"candidates as another player", "/candidates?player_id=" + guest.PlayerID, bearer(host.Token), http.StatusForbidden, api.CodeForbidden},
+		{"host starting as itself", "/start?player_id=" + host.PlayerID, bearer(host.Token), http.StatusOK, ""},
+		{"candidates", "/candidates", bearer(guest.Token), http.StatusOK, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			method := http.MethodPost
+			if strings.HasPrefix(tt.path, "/candidates") {
+				method = http.MethodGet
+			}
+			rec := serve(t, app, method, roomPath+tt.path, nil, tt.header)
+			if tt.wantCode == "" {
+				if rec.Code != tt.wantStatus {
+					t.Errorf("%s = %d %s, want %d", tt.path, rec.Code, rec.Body, tt.wantStatus)
+				}
+				return
+			}
+			wantError(t, rec, tt.wantStatus, tt.wantCode)
+		})
+	}
+
+	// A guess names its player in the body
+	rec := serve(t, app, http.MethodPost, roomPath+"/guess", api.RoomGuessRequest{PlayerID: host.PlayerID, Guess: "SLATE"}, bearer(guest.Token))
+	wantError(t, rec, http.StatusForbidden, api.CodeForbidden)
+	var progress api.RoomProgressResponse
+	decode(t, serve(t, app, http.MethodGet, roomPath+"/progress", nil, nil), &progress)
+	for _, player := range progress.Players {
+		if len(player.History) != 0 {
+			t.Errorf("player %s has guesses %v after a forbidden guess", player.PlayerID, player.History)
+		}
+	}
+}
+
+func TestRandomIDs(t *testing.T) {
+	seen := make(map[string]bool)
+	for range 100 {
+		roomID := newRoomID()
+		if len(roomID) != roomIDLength || strings.Trim(roomID, roomIDAlphabet) != "" {
+			t.Errorf("newRoomID() = %q, want %d characters from %s", roomID, roomIDLength, roomIDAlphabet)
+		}
+		token, hash := newPlayerToken()
+		if hash != hashToken(token) || strings.Contains(hash, token) {
+			t.Errorf("newPlayerToken() hash %q does not hide token %q", hash, token)
+		}
+		for _, id := range []string{roomID, newGameID(), newPlayerID(), token} {
+			if seen[id] {
+				t.Errorf("ID %q issued twice", id)
+			}
+			seen[id] = true
+		}
+	}
+}
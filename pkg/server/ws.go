@@ -0,0 +1,210 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/admin/wordle/internal/game"
+	"github.com/admin/wordle/pkg/api"
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+)
+
+// WebSocket keepalive settings
+const (
+	wsWriteWait  = 10 * time.Second
+	wsPongWait   = 60 * time.Second
+	wsPingPeriod = wsPongWait * 9 / 10
+)
+
+// upgrader upgrades room WebSocket requests
+// The default origin check accepts non-browser clients and same-origin pages.
+var upgrader = websocket.Upgrader{
+	ReadBufferSize:  1024,
+	WriteBufferSize: 4096,
+}
+
+// wsConn serializes writes to a WebSocket connection
+type wsConn struct {
+	conn *websocket.Conn
+	mu   sync.Mutex
+}
+
+// send writes a message to the client
+func (w *wsConn) send(msg api.WSServerMessage) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.conn.SetWriteDeadline(time.Now().Add(wsWriteWait))
+	return w.conn.WriteJSON(msg)
+}
+
+// close sends a close message with code and reason
+func (w *wsConn) close(code int, reason string) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	message := websocket.FormatCloseMessage(code, reason)
+	return w.conn.WriteControl(websocket.CloseMessage, message, time.Now().Add(wsWriteWait))
+}
+
+// HandleRoomWebSocket streams room progress over a WebSocket and accepts
+// guess, start and leave messages from the player holding the token
+// The token may be sent in the Authorization header or, for browsers, as the
+// token query parameter. Without one the connection is read-only.
+func (s *Server) HandleRoomWebSocket(c *gin.Context) {
+	room, ok := s.findRoom(c, c.Param("id"))
+	if !ok {
+		return
+	}
+
+	token := bearerToken(c)
+	if token == "" {
+		token = c.Query("token")
+	}
+	playerID := ""
+	if token != "" {
+		var err error
+		playerID, err = room.Authenticate(token)
+		if err != nil {
+			writeError(c, err)
+			return
+		}
+		addLogAttrs(c, slog.String("player_id", playerID))
+	}
+
+	if !s.addRoomListener(c, room) {
+		return
+	}
+	defer room.removeListener()
+
+	// Hijacked connections are not drained by http.Server.Shutdown, so count
+	// the socket before upgrading, while the request is still tracked
+	s.sockets.Add(1)
+	defer s.sockets.Done()
+
+	conn, err := upgrader.Upgrade(c.Writer, c.Request, nil)
+	if err != nil {
+		// Upgrade has already written an HTTP error
+		return
+	}
+	defer conn.Close()
+	ws := &wsConn{conn: conn}
+
+	ctx, cancel := s.waitContext(c)
+	defer cancel()
+
+	go s.readRoomMessages(ctx, cancel, ws, room, playerID)
+	go pingLoop(ctx, ws)
+
+	// Push progress on connect and after every change
+	for {
+		if room.IsExpired() {
+			ws.send(api.WSServerMessage{Type: api.WSExpired, Error: ErrRoomExpired.Error()})
+			return
+		}
+
+		progress := room.GetProgress()
+		if err := ws.send(api.WSServerMessage{Type: api.WSProgress, Progress: progress}); err != nil {
+			return
+		}
+
+		if !room.WaitForUpdate(ctx, progress.Version) {
+			if s.ShuttingDown() {
+				ws.send(api.WSServerMessage{Type: api.WSProgress, Progress: shutdownProgress(room.GetProgress())})
+				ws.close(websocket.CloseGoingAway, ErrShuttingDown.Error())
+			}
+			return
+		}
+	}
+}
+
+// readRoomMessages handles client messages until the connection fails,
+// then cancels the connection's context
+func (s *Server) readRoomMessages(ctx context.Context, cancel context.CancelFunc, ws *wsConn, room *Room, playerID string) {
+	defer cancel()
+
+	ws.conn.SetReadDeadline(time.Now().Add(wsPongWait))
+	ws.conn.SetPongHandler(func(string) error {
+		return ws.conn.SetReadDeadline(time.Now().Add(wsPongWait))
+	})
+
+	for ctx.Err() == nil {
+		var msg api.WSClientMessage
+		if err := ws.conn.ReadJSON(&msg); err != nil {
+			if websocket.IsUnexpectedCloseError(err, websocket.CloseGoingAway, websocket.CloseNormalClosure) {
+				slog.Warn("websocket read failed", "room_id", room.ID, "player_id", playerID, "err", err)
+			}
+			return
+		}
+
+		reply := s.handleRoomMessage(room, playerID, msg)
+		reply.ID = msg.ID
+		slog.Debug("websocket message", "room_id", room.ID, "player_id", playerID,
+			"type", msg.Type, "reply", reply.Type, "error", reply.Error)
+		if err := ws.send(reply); err != nil {
+			return
+		}
+		if reply.Type == api.WSLeave {
+			return
+		}
+	}
+}
+
+// handleRoomMessage performs a client action and returns the reply
+func (s *Server) handleRoomMessage(room *Room, playerID string, msg api.WSClientMessage) api.WSServerMessage {
+	if playerID == "" {
+		return wsError(ErrMissingToken)
+	}
+
+	switch msg.Type {
+	case api.WSGuess:
+		if !game.ValidateWordLength(msg.Guess, room.WordLength) {
+			s.metrics.rejectedGuess(modeRoom, rejectInvalidWord)
+			return wsError(fmt.Errorf("%w: must be %d letters, alphabetic only", game.ErrInvalidWord, room.WordLength))
+		}
+		result, err := room.MakeGuess(playerID, msg.Guess)
+		if err != nil {
+			return wsError(err)
+		}
+		return api.WSServerMessage{Type: api.WSGuess, Result: result}
+
+	case api.WSStart:
+		if err := room.StartGame(playerID); err != nil {
+			return wsError(err)
+		}
+		return api.WSServerMessage{Type: api.WSStart, Message: "Game started!"}
+
+	case api.WSLeave:
+		if err := room.LeaveRoom(playerID); err != nil {
+			return wsError(err)
+		}
+		return api.WSServerMessage{Type: api.WSLeave, Message: "Left room successfully"}
+
+	default:
+		return api.WSServerMessage{
+			Type:  api.WSError,
+			Error: fmt.Sprintf("unknown message type: %s", msg.Type),
+			Code:  api.CodeInvalidRequest,
+		}
+	}
+}
+
+// pingLoop keeps the connection alive until ctx is done
+func pingLoop(ctx context.Context, ws *wsConn) {
+	ticker := time.NewTicker(wsPingPeriod)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			// WriteControl may be called concurrently with WriteJSON
+			if err := ws.conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(wsWriteWait)); err != nil {
+				return
+			}
+		}
+	}
+}
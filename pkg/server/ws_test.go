@@ -0,0 +1,198 @@
+package server
+
+import (
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/admin/wordle/pkg/api"
+	"github.com/gorilla/websocket"
+)
+
+// dialRoom opens a room WebSocket on srv, with token unless it is empty,
+// and reads the progress sent on connect
+func dialRoom(t *testing.T, srv *httptest.Server, roomID, token string) *websocket.Conn {
+	t.Helper()
+	var header http.Header
+	if token != "" {
+		header = bearer(token)
+	}
+	url := "ws" + strings.TrimPrefix(srv.URL, "http") + "/v1/room/" + roomID + "/ws"
+	conn, resp, err := websocket.DefaultDialer.Dial(url, header)
+	if err != nil {
+		t.Fatalf("dial %s: %v (%v)", url, err, resp)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	if msg := readWS(t, conn); msg.Type != api.WSProgress {
+		t.Fatalf("first message = %+v, want progress", msg)
+	}
+	return conn
+}
+
+// readWS reads the next message from conn
+func readWS(t *testing.T, conn *websocket.Conn) api.WSServerMessage {
+	t.Helper()
+	conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	var msg api.WSServerMessage
+	if err := conn.ReadJSON(&msg); err != nil {
+		t.Fatalf("read: %v", err)
+	}
+	return msg
+}
+
+// readReply reads from conn until the reply to the message with id,
+// skipping the progress pushed in between
+func readReply(t *testing.T, conn *websocket.Conn, id string) api.WSServerMessage {
+	t.Helper()
+	for {
+		if msg := readWS(t, conn); msg.ID == id {
+			return msg
+		}
+	}
+}
+
+// newWSServer serves app over HTTP and returns a room with a host and a guest
+func newWSServer(t *testing.T, app *App) (*httptest.Server, api.CreateRoomResponse, api.JoinRoomResponse) {
+	t.Helper()
+	srv := httptest.NewServer(app.router)
+	t.Cleanup(srv.Close)
+	host := createRoom(t, app)
+	return srv, host, joinRoom(t, app, host.RoomID)
+}
+
+func TestRoomWebSocketActions(t *testing.T) {
+	app := newTestApp(t, nil)
+	srv, host, guest := newWSServer(t, app)
+	conns := map[string]*websocket.Conn{
+		"host":    dialRoom(t, srv, host.RoomID, host.Token),
+		"guest":   dialRoom(t, srv, host.RoomID, guest.Token),
+		"watcher": dialRoom(t, srv, host.RoomID, ""),
+	}
+
+	// Each step depends on the ones before it
+	steps := []struct {
+		name     string
+		conn     string
+		msg      api.WSClientMessage
+		wantType string
+		wantCode string // For WSError
+	}{
+		{"read-only guess", "watcher", api.WSClientMessage{Type: api.WSGuess, Guess: "SLATE"}, api.WSError, api.CodeUnauthorized},
+		{"read-only start", "watcher", api.WSClientMessage{Type: api.WSStart}, api.WSError, api.CodeUnauthorized},
+		{"unknown type", "host", api.WSClientMessage{Type: "dance"}, api.WSError, api.CodeInvalidRequest},
+		{"guess before start", "host", api.WSClientMessage{Type: api.WSGuess, Guess: "SLATE"}, api.WSError, api.CodeRoomNotPlaying},
+		{"guest starting", "guest", api.WSClientMessage{Type: api.WSStart}, api.WSError, api.CodeNotHost},
+		{"start", "host", api.WSClientMessage{Type: api.WSStart}, api.WSStart, ""},
+		{"invalid word", "host", api.WSClientMessage{Type: api.WSGuess, Guess: "CR4NE"}, api.WSError, api.CodeInvalidWord},
+		{"guess", "host", api.WSClientMessage{Type: api.WSGuess, Guess: "SLATE"}, api.WSGuess, ""},
+		{"leave", "guest", api.WSClientMessage{Type: api.WSLeave}, api.WSLeave, ""},
+	}
+
+	for i, step := range steps {
+		step.msg.ID = strconv.Itoa(i + 1)
+		conn := conns[step.conn]
+		if err := conn.WriteJSON(step.msg); err != nil {
+			t.Fatalf("%s: write: %v", step.name, err)
+		}
+		reply := readReply(t, conn, step.msg.ID)
+		if reply.Type != step.wantType || reply.Code != step.wantCode {
+			t.Errorf("%s: reply = %+v, want type %s code %q", step.name, reply, step.wantType, step.wantCode)
+		}
+		if step.wantType == api.WSGuess && (reply.Result == nil || reply.Result.Guess != step.msg.Guess) {
+			t.Errorf("%s: result = %+v, want the guess %s", step.name, reply.Result, step.msg.Guess)
+		}
+	}
+
+	// The watcher was pushed every change, ending with the guest leaving
+	// after the host's guess
+	for {
+		msg := readWS(t, conns["watcher"])
+		if msg.Type != api.WSProgress {
+			t.Fatalf("watcher got %+v, want only progress", msg)
+		}
+		if len(msg.Progress.Players) == 1 {
+			if player := msg.Progress.Players[0]; player.PlayerID != host.PlayerID || len(player.History) != 1 {
+				t.Errorf("progress players = %+v, want the host with one guess", msg.Progress.Players)
+			}
+			break
+		}
+	}
+
+	// Leaving ends the guest's connection
+	conns["guest"].SetReadDeadline(time.Now().Add(5 * time.Second))
+	for {
+		if _, _, err := conns["guest"].ReadMessage(); err != nil {
+			if netErr, ok := err.(net.Error); ok && netErr.Timeout() {
+				t.Error("guest connection still open after leaving")
+			}
+			break
+		}
+	}
+}
+
+func TestRoomWebSocketRefused(t *testing.T) {
+	app := newTestApp(t, nil)
+	srv, host, _ := newWSServer(t, app)
+	url := "ws" + strings.TrimPrefix(srv.URL, "http") + "/v1/room/"
+
+	tests := []struct {
+		name       string
+		path       string
+		header     http.Header
+		wantStatus int
+	}{
+		{"unknown room", "NOROOM/ws", bearer(host.Token), http.StatusNotFound},
+		{"invalid token", host.RoomID + "/ws", bearer("not-a-token"), http.StatusUnauthorized},
+		{"invalid query token", host.RoomID + "/ws?token=not-a-token", nil, http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conn, resp, err := websocket.DefaultDialer.Dial(url+tt.path, tt.header)
+			if err == nil {
+				conn.Close()
+				t.Fatal("dial succeeded, want it refused")
+			}
+			if resp == nil || resp.StatusCode != tt.wantStatus {
+				t.Errorf("dial = %v, %v; want status %d", resp, err, tt.wantStatus)
+			}
+		})
+	}
+}
+
+func TestRoomWebSocketExpired(t *testing.T) {
+	app := newTestApp(t, nil)
+	srv, host, _ := newWSServer(t, app)
+	conn := dialRoom(t, srv, host.RoomID, "")
+
+	app.server.Sweep(time.Now().Add(time.Hour))
+	if msg := readWS(t, conn); msg.Type != api.WSExpired || msg.Error != ErrRoomExpired.Error() {
+		t.Errorf("message after expiry = %+v, want expired", msg)
+	}
+	_, _, err := conn.ReadMessage()
+	if netErr, ok := err.(net.Error); err == nil || ok && netErr.Timeout() {
+		t.Error("connection still open after the expired message")
+	}
+}
+
+func TestRoomWebSocketShutdown(t *testing.T) {
+	app := newTestApp(t, nil)
+	srv, host, _ := newWSServer(t, app)
+	conn := dialRoom(t, srv, host.RoomID, host.Token)
+
+	app.server.BeginShutdown()
+	app.server.ReleaseWaiters()
+	msg := readWS(t, conn)
+	if msg.Type != api.WSProgress || msg.Progress == nil || msg.Progress.Message != ErrShuttingDown.Error() {
+		t.Errorf("goodbye = %+v, want progress with the shutdown message", msg)
+	}
+	_, _, err := conn.ReadMessage()
+	if !websocket.IsCloseError(err, websocket.CloseGoingAway) {
+		t.Errorf("read after goodbye = %v, want close going away", err)
+	}
+}